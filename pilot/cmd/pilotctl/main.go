@@ -0,0 +1,295 @@
+// Command pilotctl is a headless command-line companion to the Pilot.
+// It drives the same ipc.Controller and session.Manager used by the Fyne
+// dashboard, so recordings can be made over SSH or on a machine with no
+// display. Sessions it records share the same on-disk layout, so they
+// show up later in the Fyne Dashboard.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/topnotchnotes/pilot/internal/ipc"
+	"github.com/topnotchnotes/pilot/internal/session"
+	"github.com/topnotchnotes/pilot/internal/tui"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	harnessPath := findHarnessBinary()
+	sessManager := session.NewManager(getDataDir(), resolveUserID())
+	defer sessManager.Close()
+	controller := ipc.NewController(harnessPath)
+
+	cmd, args := os.Args[1], os.Args[2:]
+
+	var err error
+	switch cmd {
+	case "record":
+		err = runRecord(controller, sessManager, args)
+	case "stop":
+		err = runAction(controller, controller.Stop)
+	case "pause":
+		err = runAction(controller, controller.Pause)
+	case "resume":
+		err = runAction(controller, controller.Resume)
+	case "status":
+		err = runStatus(controller)
+	case "sessions":
+		err = runSessions(sessManager, args)
+	case "session":
+		err = runSessionBundle(sessManager, args)
+	case "watch":
+		err = runWatch(controller, args)
+	case "tui":
+		err = runTUI(controller, sessManager)
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pilotctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: pilotctl <command> [args]
+
+commands:
+  record [course] [name]   start a recording session
+  stop                     stop the current recording
+  pause                    pause the current recording
+  resume                   resume a paused recording
+  status                   print the current harness status
+  sessions list            list recorded sessions
+  sessions show <id>       show details for a session
+  session export <id> <file>   export a session as a .tnn bundle
+  session import <file>        import a .tnn bundle as a new session
+  watch [--json]           stream telemetry events
+  tui                      open the interactive terminal dashboard`)
+}
+
+// runAction starts the harness, performs a single control action, and
+// waits briefly for the harness to acknowledge it before terminating.
+func runAction(controller *ipc.Controller, action func() error) error {
+	if err := controller.Start(); err != nil {
+		return fmt.Errorf("failed to start harness: %w", err)
+	}
+	defer controller.Terminate()
+
+	if err := action(); err != nil {
+		return err
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	fmt.Printf("state: %s\n", controller.State())
+	return nil
+}
+
+// runRecord creates a session, starts the harness, and records until the
+// user sends SIGINT, at which point it stops and saves the session.
+func runRecord(controller *ipc.Controller, sessManager *session.Manager, args []string) error {
+	var courseID, name string
+	if len(args) > 0 {
+		courseID = args[0]
+	}
+	if len(args) > 1 {
+		name = args[1]
+	}
+
+	sess, err := sessManager.CreateSession(courseID, name)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	controller.SetOutputDir(sessManager.GetSessionDir(courseID, sess.ID))
+
+	if err := controller.Start(); err != nil {
+		return fmt.Errorf("failed to start harness: %w", err)
+	}
+	defer controller.Terminate()
+
+	controller.OnEvent(func(event ipc.TelemetryEvent) {
+		if event.Event == ipc.EventText {
+			fmt.Println(event.Body)
+		}
+	})
+
+	if err := controller.StartRecording(); err != nil {
+		return fmt.Errorf("failed to start recording: %w", err)
+	}
+	fmt.Printf("recording session %s (ctrl-c to stop)\n", sess.ID)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	<-sigCh
+
+	if err := controller.Stop(); err != nil {
+		return fmt.Errorf("failed to stop recording: %w", err)
+	}
+	return sessManager.UpdateSession(sess)
+}
+
+func runStatus(controller *ipc.Controller) error {
+	if err := controller.Start(); err != nil {
+		return fmt.Errorf("failed to start harness: %w", err)
+	}
+	defer controller.Terminate()
+
+	if err := controller.Status(); err != nil {
+		return err
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	fmt.Printf("state:     %s\n", controller.State())
+	fmt.Printf("recording: %t\n", controller.IsRecording())
+	fmt.Printf("level:     %.1f dB\n", controller.LastLevel())
+	fmt.Printf("session:   %s\n", controller.SessionID())
+	return nil
+}
+
+func runSessions(sessManager *session.Manager, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: pilotctl sessions list|show <id>")
+	}
+
+	switch args[0] {
+	case "list":
+		for _, s := range sessManager.ListSessions("") {
+			fmt.Printf("%s\t%s\t%s\n", s.ID, s.CreatedAt.Format(time.RFC3339), s.Name)
+		}
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: pilotctl sessions show <id>")
+		}
+		sess, ok := sessManager.GetSession(args[1])
+		if !ok {
+			return fmt.Errorf("no such session: %s", args[1])
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sess)
+	default:
+		return fmt.Errorf("unknown sessions subcommand: %s", args[0])
+	}
+	return nil
+}
+
+func runSessionBundle(sessManager *session.Manager, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: pilotctl session export <id> <file> | import <file>")
+	}
+
+	switch args[0] {
+	case "export":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: pilotctl session export <id> <file>")
+		}
+		return sessManager.ExportSession(args[1], args[2])
+	case "import":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: pilotctl session import <file>")
+		}
+		sess, err := sessManager.ImportSession(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported session %s\n", sess.ID)
+		return nil
+	default:
+		return fmt.Errorf("unknown session subcommand: %s", args[0])
+	}
+}
+
+func runWatch(controller *ipc.Controller, args []string) error {
+	jsonOut := len(args) > 0 && args[0] == "--json"
+
+	if err := controller.Start(); err != nil {
+		return fmt.Errorf("failed to start harness: %w", err)
+	}
+	defer controller.Terminate()
+
+	controller.OnEvent(func(event ipc.TelemetryEvent) {
+		if jsonOut {
+			data, _ := json.Marshal(event)
+			fmt.Println(string(data))
+			return
+		}
+		fmt.Printf("%-10s %s\n", event.Event, event.Body)
+	})
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	<-sigCh
+	return nil
+}
+
+func runTUI(controller *ipc.Controller, sessManager *session.Manager) error {
+	if err := controller.Start(); err != nil {
+		return fmt.Errorf("failed to start harness: %w", err)
+	}
+	defer controller.Terminate()
+
+	return tui.Run(controller, sessManager)
+}
+
+// findHarnessBinary locates the C++ harness binary. It mirrors the
+// lookup used by the Fyne entry point so both binaries find the harness
+// the same way.
+func findHarnessBinary() string {
+	candidates := []string{
+		"./harness",
+		"./bin/harness",
+		"../harness/build/harness",
+		"/usr/local/bin/topnotch-harness",
+	}
+
+	execPath, err := os.Executable()
+	if err == nil {
+		execDir := filepath.Dir(execPath)
+		candidates = append([]string{
+			filepath.Join(execDir, "harness"),
+			filepath.Join(execDir, "..", "harness", "build", "harness"),
+		}, candidates...)
+	}
+
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return "./harness"
+}
+
+// resolveUserID picks the user whose data pilotctl should operate on.
+// pilotctl is a local, single-operator tool, so it trusts $PILOT_USER
+// rather than asking for a password the way the Fyne dashboard's login
+// window does; it falls back to the default bootstrap account.
+func resolveUserID() string {
+	if user := os.Getenv("PILOT_USER"); user != "" {
+		return user
+	}
+	return "admin"
+}
+
+// getDataDir returns the application data directory, matching the layout
+// used by the Fyne dashboard so sessions are shared between the two.
+func getDataDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	dataDir := filepath.Join(configDir, "TopNotchNotes")
+	os.MkdirAll(dataDir, 0755)
+	return dataDir
+}
@@ -0,0 +1,222 @@
+// Package auth manages local Pilot user accounts so recordings can be
+// isolated per user instead of shared by everyone with access to the
+// machine.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id parameters, per OWASP's current minimum recommendation for
+// interactive logins.
+const (
+	argon2Memory      = 64 * 1024 // KiB, i.e. 64 MiB
+	argon2Iterations  = 3
+	argon2Parallelism = 2
+	argon2KeyLength   = 32
+	saltLength        = 16
+)
+
+// usersFileName is the Store's on-disk file, relative to the data
+// directory it is opened with. It is shared across all users, unlike
+// the per-user directories under session.Manager.
+const usersFileName = "users.json"
+
+// User is a local Pilot account.
+type User struct {
+	Username     string    `json:"username"`
+	Argon2idHash string    `json:"argon2id_hash"`
+	Salt         string    `json:"salt"`
+	CreatedAt    time.Time `json:"created_at"`
+	IsAdmin      bool      `json:"is_admin"`
+}
+
+// Store manages user accounts, persisted as a single users.json file.
+type Store struct {
+	path string
+
+	mu    sync.RWMutex
+	users map[string]*User
+}
+
+// NewStore opens (or creates) the user store under dataDir. If it has
+// no users yet, a default "admin" account is bootstrapped with a
+// randomly generated password, which is returned so the caller can
+// show it to the operator exactly once.
+func NewStore(dataDir string) (*Store, string, error) {
+	s := &Store{
+		path:  filepath.Join(dataDir, usersFileName),
+		users: make(map[string]*User),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, "", fmt.Errorf("failed to load users: %w", err)
+	}
+
+	var bootstrapPassword string
+	if len(s.users) == 0 {
+		password, err := randomPassword()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate bootstrap password: %w", err)
+		}
+		if err := s.CreateUser("admin", password, true); err != nil {
+			return nil, "", fmt.Errorf("failed to bootstrap admin user: %w", err)
+		}
+		bootstrapPassword = password
+	}
+
+	return s, bootstrapPassword, nil
+}
+
+// CreateUser adds a new account with an Argon2id-hashed password.
+func (s *Store) CreateUser(username, password string, isAdmin bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[username]; exists {
+		return fmt.Errorf("user already exists: %s", username)
+	}
+
+	encoded, salt, err := hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	s.users[username] = &User{
+		Username:     username,
+		Argon2idHash: encoded,
+		Salt:         salt,
+		CreatedAt:    time.Now(),
+		IsAdmin:      isAdmin,
+	}
+
+	return s.save()
+}
+
+// Verify checks a username/password pair against the stored hash,
+// using a constant-time comparison, and returns the user on success.
+func (s *Store) Verify(username, password string) (*User, bool) {
+	s.mu.RLock()
+	user, ok := s.users[username]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	match, err := verifyPassword(password, user.Argon2idHash)
+	if err != nil || !match {
+		return nil, false
+	}
+	return user, true
+}
+
+// hashPassword derives an Argon2id hash for password under a fresh
+// random salt, returning the full PHC-style encoded hash alongside the
+// salt on its own for quick access.
+func hashPassword(password string) (encoded, saltB64 string, err error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", "", err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, argon2Iterations, argon2Memory, argon2Parallelism, argon2KeyLength)
+
+	saltB64 = base64.RawStdEncoding.EncodeToString(salt)
+	hashB64 := base64.RawStdEncoding.EncodeToString(hash)
+
+	encoded = fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Iterations, argon2Parallelism, saltB64, hashB64)
+
+	return encoded, saltB64, nil
+}
+
+// verifyPassword re-derives a hash from password using the parameters
+// and salt embedded in encoded, then compares it to the stored hash in
+// constant time.
+func verifyPassword(password, encoded string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, fmt.Errorf("malformed encoded hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, fmt.Errorf("malformed version: %w", err)
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, fmt.Errorf("malformed parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("malformed salt: %w", err)
+	}
+	wantHash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("malformed hash: %w", err)
+	}
+
+	gotHash := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(wantHash)))
+
+	return subtle.ConstantTimeCompare(gotHash, wantHash) == 1, nil
+}
+
+// randomPassword generates a random password for the bootstrap admin
+// account.
+func randomPassword() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// load reads users.json, if it exists.
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var users []*User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return err
+	}
+	for _, u := range users {
+		s.users[u.Username] = u
+	}
+	return nil
+}
+
+// save rewrites users.json with the current set of accounts.
+func (s *Store) save() error {
+	users := make([]*User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal users: %w", err)
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
@@ -0,0 +1,99 @@
+package auth
+
+import "testing"
+
+func TestHashPasswordVerifyPasswordRoundTrip(t *testing.T) {
+	encoded, salt, err := hashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("hashPassword failed: %v", err)
+	}
+	if salt == "" {
+		t.Error("expected a non-empty salt")
+	}
+
+	match, err := verifyPassword("correct horse battery staple", encoded)
+	if err != nil {
+		t.Fatalf("verifyPassword failed: %v", err)
+	}
+	if !match {
+		t.Error("expected the correct password to verify")
+	}
+
+	match, err = verifyPassword("wrong password", encoded)
+	if err != nil {
+		t.Fatalf("verifyPassword failed: %v", err)
+	}
+	if match {
+		t.Error("expected an incorrect password not to verify")
+	}
+}
+
+func TestHashPasswordUsesAFreshSaltEachTime(t *testing.T) {
+	encoded1, _, err := hashPassword("same password")
+	if err != nil {
+		t.Fatalf("hashPassword failed: %v", err)
+	}
+	encoded2, _, err := hashPassword("same password")
+	if err != nil {
+		t.Fatalf("hashPassword failed: %v", err)
+	}
+
+	if encoded1 == encoded2 {
+		t.Error("expected two hashes of the same password to differ due to random salts")
+	}
+}
+
+func TestStoreVerify(t *testing.T) {
+	dataDir := t.TempDir()
+
+	store, bootstrapPassword, err := NewStore(dataDir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if bootstrapPassword == "" {
+		t.Fatal("expected a bootstrap password for a fresh store")
+	}
+
+	if _, ok := store.Verify("admin", bootstrapPassword); !ok {
+		t.Error("expected the bootstrap admin account to verify with its generated password")
+	}
+	if _, ok := store.Verify("admin", "not the password"); ok {
+		t.Error("expected Verify to reject a wrong password")
+	}
+	if _, ok := store.Verify("nobody", bootstrapPassword); ok {
+		t.Error("expected Verify to reject an unknown username")
+	}
+
+	if err := store.CreateUser("alice", "alicepassword", false); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if _, ok := store.Verify("alice", "alicepassword"); !ok {
+		t.Error("expected a newly created user to verify with its own password")
+	}
+	if _, ok := store.Verify("alice", bootstrapPassword); ok {
+		t.Error("expected a user's password not to verify against another user's password")
+	}
+}
+
+func TestStorePersistsAcrossReopen(t *testing.T) {
+	dataDir := t.TempDir()
+
+	store, _, err := NewStore(dataDir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := store.CreateUser("alice", "alicepassword", false); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	reopened, bootstrapPassword, err := NewStore(dataDir)
+	if err != nil {
+		t.Fatalf("reopening NewStore failed: %v", err)
+	}
+	if bootstrapPassword != "" {
+		t.Error("expected no new bootstrap password when users.json already has accounts")
+	}
+	if _, ok := reopened.Verify("alice", "alicepassword"); !ok {
+		t.Error("expected alice's account to survive closing and reopening the store")
+	}
+}
@@ -4,11 +4,13 @@ package ipc
 
 import (
 	"bufio"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os/exec"
+	"strings"
 	"sync"
 	"time"
 )
@@ -58,34 +60,157 @@ type TelemetryEvent struct {
 // EventHandler is a callback for telemetry events
 type EventHandler func(event TelemetryEvent)
 
+// legacyScanBufferSize/Max size the bufio.Scanner used for the
+// line-delimited protocol, well above its default 64KB token limit so
+// long EventText bodies aren't silently dropped.
+const (
+	legacyScanBufferSize = 64 * 1024
+	legacyScanBufferMax  = 4 * 1024 * 1024
+)
+
+// framedMaxPayloadSize bounds both a single frame's length prefix and
+// the cumulative size a multi-frame stream can reassemble to, matching
+// legacyScanBufferMax's role for the line-delimited protocol: a corrupt
+// or hostile frame header (or an endless run of sub-limit frames on one
+// stream_id) shouldn't be able to trigger unbounded allocation.
+const framedMaxPayloadSize = 4 * 1024 * 1024
+
+// framedMode selects how listenTelemetry interprets stdout.
+type framedMode int
+
+const (
+	// framedAuto inspects the first byte of the stream: '{' means the
+	// legacy line-delimited protocol, anything else means framed.
+	framedAuto framedMode = iota
+	framedLegacy
+	framedForced
+)
+
+// wireFrame is the framed-protocol envelope: a 4-byte big-endian length
+// prefix (handled by listenFramed) followed by this JSON body. Most
+// frames carry one complete TelemetryEvent; a large payload can instead
+// be split across multiple frames that share a StreamID, with Final set
+// on the last chunk.
+type wireFrame struct {
+	TelemetryEvent
+	StreamID string `json:"stream_id,omitempty"`
+	Final    bool   `json:"final,omitempty"`
+}
+
+// LevelSample is a single point in the rolling dB level history.
+type LevelSample struct {
+	Ts int64   `json:"ts"`
+	DB float64 `json:"db"`
+}
+
+// DefaultLevelHistorySize is how many LevelSamples the controller keeps,
+// roughly one minute of history at a 10Hz EventLevel rate.
+const DefaultLevelHistorySize = 600
+
+// levelHistoryCoalesceWindow bounds how often OnLevelHistory handlers
+// are notified, so a fast stream of EventLevel updates doesn't thrash
+// the UI.
+const levelHistoryCoalesceWindow = 50 * time.Millisecond
+
+// LevelHistoryHandler is a callback for rolling level history updates.
+type LevelHistoryHandler func(history []LevelSample)
+
+// DefaultHeartbeatTimeout is how long the controller waits without
+// hearing from the harness (any event counts, not just EventHeartbeat)
+// before it considers the process dead.
+const DefaultHeartbeatTimeout = 5 * time.Second
+
+// maxBackoff caps the delay between restart attempts.
+const maxBackoff = 30 * time.Second
+
 // Controller manages the harness subprocess and IPC
 type Controller struct {
 	binaryPath string
 	outputDir  string
-	
+
 	cmd    *exec.Cmd
 	stdin  io.WriteCloser
 	stdout io.ReadCloser
 	stderr io.ReadCloser
-	
+
 	mu         sync.RWMutex
 	state      string
 	recording  bool
 	sessionID  string
 	lastLevel  float64
-	
+	healthy    bool
+
+	heartbeatTimeout time.Duration
+	autoRestart      bool
+	watchdogReset    chan struct{}
+
+	framedMode framedMode
+
+	levelHistory     []LevelSample
+	levelHistorySize int
+	levelHistoryMu   sync.Mutex
+
+	levelHistoryHandlers   []LevelHistoryHandler
+	levelHistoryHandlersMu sync.RWMutex
+	levelHistoryNotifyMu   sync.Mutex
+	levelHistoryLastNotify time.Time
+	levelHistoryPending    bool
+
 	handlers   []EventHandler
 	handlersMu sync.RWMutex
-	
+
 	done chan struct{}
 }
 
 // NewController creates a new harness controller
 func NewController(binaryPath string) *Controller {
 	return &Controller{
-		binaryPath: binaryPath,
-		state:      "idle",
-		done:       make(chan struct{}),
+		binaryPath:       binaryPath,
+		state:            "idle",
+		healthy:          true,
+		heartbeatTimeout: DefaultHeartbeatTimeout,
+		watchdogReset:    make(chan struct{}, 1),
+		levelHistorySize: DefaultLevelHistorySize,
+		done:             make(chan struct{}),
+	}
+}
+
+// SetLevelHistorySize overrides how many LevelSamples are retained in
+// the rolling history.
+func (c *Controller) SetLevelHistorySize(n int) {
+	c.levelHistoryMu.Lock()
+	defer c.levelHistoryMu.Unlock()
+	c.levelHistorySize = n
+	if len(c.levelHistory) > n {
+		c.levelHistory = c.levelHistory[len(c.levelHistory)-n:]
+	}
+}
+
+// SetHeartbeatTimeout overrides how long the watchdog waits for a
+// heartbeat before declaring the harness unresponsive.
+func (c *Controller) SetHeartbeatTimeout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.heartbeatTimeout = d
+}
+
+// SetAutoRestart enables or disables automatic relaunch of the harness
+// after a watchdog timeout.
+func (c *Controller) SetAutoRestart(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoRestart = enabled
+}
+
+// UseFramed forces the framed or legacy line-delimited protocol instead
+// of auto-detecting it from the first byte of stdout.
+func (c *Controller) UseFramed(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if enabled {
+		c.framedMode = framedForced
+	} else {
+		c.framedMode = framedLegacy
 	}
 }
 
@@ -103,74 +228,292 @@ func (c *Controller) OnEvent(handler EventHandler) {
 	c.handlers = append(c.handlers, handler)
 }
 
-// Start launches the harness subprocess
+// Start launches the harness subprocess and the watchdog that supervises
+// it.
 func (c *Controller) Start() error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	
+	err := c.launchLocked()
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go c.watchdog()
+
+	return nil
+}
+
+// launchLocked starts the harness subprocess and its telemetry readers.
+// The caller must hold c.mu.
+func (c *Controller) launchLocked() error {
 	c.cmd = exec.Command(c.binaryPath, "-v")
-	
+
 	var err error
 	c.stdin, err = c.cmd.StdinPipe()
 	if err != nil {
 		return fmt.Errorf("failed to get stdin pipe: %w", err)
 	}
-	
+
 	c.stdout, err = c.cmd.StdoutPipe()
 	if err != nil {
 		return fmt.Errorf("failed to get stdout pipe: %w", err)
 	}
-	
+
 	c.stderr, err = c.cmd.StderrPipe()
 	if err != nil {
 		return fmt.Errorf("failed to get stderr pipe: %w", err)
 	}
-	
+
 	if err := c.cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start harness: %w", err)
 	}
-	
+
 	// Start the telemetry listener
 	go c.listenTelemetry()
 	go c.logStderr()
-	
+
 	return nil
 }
 
+// watchdog resets a timer every time an event arrives and, if it ever
+// fires, treats the harness as dead and (optionally) relaunches it.
+func (c *Controller) watchdog() {
+	c.mu.RLock()
+	timeout := c.heartbeatTimeout
+	c.mu.RUnlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case <-c.watchdogReset:
+			c.mu.RLock()
+			timeout = c.heartbeatTimeout
+			c.mu.RUnlock()
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+
+		case <-timer.C:
+			c.handleWatchdogTimeout()
+			timer.Reset(timeout)
+		}
+	}
+}
+
+// handleWatchdogTimeout fires when no event has arrived within the
+// heartbeat timeout. It reports the failure, kills the subprocess, and
+// relaunches it with exponential backoff if AutoRestart is enabled.
+func (c *Controller) handleWatchdogTimeout() {
+	c.mu.Lock()
+	c.healthy = false
+	wasRecording := c.recording
+	autoRestart := c.autoRestart
+	cmd := c.cmd
+	c.mu.Unlock()
+
+	c.dispatch(TelemetryEvent{Event: EventError, Body: "heartbeat timeout: harness not responding"})
+
+	if cmd != nil && cmd.Process != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+
+	if !autoRestart {
+		return
+	}
+
+	c.dispatch(TelemetryEvent{Event: EventStatus, State: "reconnecting"})
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(backoffDelay(attempt)):
+		}
+
+		c.mu.Lock()
+		err := c.launchLocked()
+		c.mu.Unlock()
+		if err == nil {
+			break
+		}
+	}
+
+	if wasRecording {
+		c.StartRecording()
+	}
+
+	c.mu.Lock()
+	c.healthy = true
+	c.mu.Unlock()
+
+	c.dispatch(TelemetryEvent{Event: EventStatus, State: "ready"})
+}
+
+// backoffDelay returns the delay before the Nth restart attempt,
+// doubling from 500ms and capping at maxBackoff.
+func backoffDelay(attempt int) time.Duration {
+	delay := 500 * time.Millisecond
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// Healthy reports whether the harness has responded within its
+// heartbeat timeout.
+func (c *Controller) Healthy() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.healthy
+}
+
 // listenTelemetry reads and processes JSON telemetry from stdout
 func (c *Controller) listenTelemetry() {
-	scanner := bufio.NewScanner(c.stdout)
-	
+	reader := bufio.NewReaderSize(c.stdout, legacyScanBufferSize)
+
+	c.mu.RLock()
+	mode := c.framedMode
+	c.mu.RUnlock()
+
+	useFramed := mode == framedForced
+	if mode == framedAuto {
+		first, err := reader.Peek(1)
+		if err != nil {
+			return
+		}
+		useFramed = first[0] != '{'
+	}
+
+	if useFramed {
+		c.listenFramed(reader)
+	} else {
+		c.listenLegacy(reader)
+	}
+}
+
+// listenLegacy reads one JSON TelemetryEvent per line. The scanner's
+// buffer is sized generously above the default 64KB token limit so long
+// EventText bodies don't get silently dropped.
+func (c *Controller) listenLegacy(reader *bufio.Reader) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, legacyScanBufferSize), legacyScanBufferMax)
+
 	for scanner.Scan() {
 		select {
 		case <-c.done:
 			return
 		default:
 		}
-		
+
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
-		
+
 		var event TelemetryEvent
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
 			// Log but don't crash on malformed JSON
 			continue
 		}
-		
-		// Update internal state
-		c.processEvent(event)
-		
-		// Notify handlers
-		c.handlersMu.RLock()
-		for _, handler := range c.handlers {
-			handler(event)
+
+		c.resetWatchdog()
+		c.dispatch(event)
+	}
+}
+
+// listenFramed reads the framed protocol: a 4-byte big-endian length
+// prefix followed by that many bytes of JSON. Large payloads can arrive
+// split across multiple frames sharing a StreamID, reassembled here
+// before a single TelemetryEvent reaches handlers.
+func (c *Controller) listenFramed(reader *bufio.Reader) {
+	lenBuf := make([]byte, 4)
+	streams := make(map[string]*strings.Builder)
+
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+
+		if _, err := io.ReadFull(reader, lenBuf); err != nil {
+			return
+		}
+		size := binary.BigEndian.Uint32(lenBuf)
+		if size > framedMaxPayloadSize {
+			log.Printf("ipc: framed payload of %d bytes exceeds max of %d, dropping connection", size, framedMaxPayloadSize)
+			return
+		}
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return
+		}
+
+		var frame wireFrame
+		if err := json.Unmarshal(payload, &frame); err != nil {
+			continue
+		}
+
+		c.resetWatchdog()
+
+		if frame.StreamID == "" {
+			c.dispatch(frame.TelemetryEvent)
+			continue
+		}
+
+		buf, ok := streams[frame.StreamID]
+		if !ok {
+			buf = &strings.Builder{}
+			streams[frame.StreamID] = buf
+		}
+
+		if buf.Len()+len(frame.Body) > framedMaxPayloadSize {
+			log.Printf("ipc: framed stream %s exceeded %d cumulative bytes, dropping it", frame.StreamID, framedMaxPayloadSize)
+			delete(streams, frame.StreamID)
+			continue
+		}
+		buf.WriteString(frame.Body)
+
+		if frame.Final {
+			event := frame.TelemetryEvent
+			event.Body = buf.String()
+			delete(streams, frame.StreamID)
+			c.dispatch(event)
 		}
-		c.handlersMu.RUnlock()
 	}
 }
 
+// resetWatchdog notifies the watchdog goroutine that the harness is
+// still alive.
+func (c *Controller) resetWatchdog() {
+	select {
+	case c.watchdogReset <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch updates internal state for event and notifies handlers.
+func (c *Controller) dispatch(event TelemetryEvent) {
+	c.processEvent(event)
+
+	c.handlersMu.RLock()
+	for _, handler := range c.handlers {
+		handler(event)
+	}
+	c.handlersMu.RUnlock()
+}
+
 // logStderr logs stderr output from the harness
 func (c *Controller) logStderr() {
 	scanner := bufio.NewScanner(c.stderr)
@@ -185,16 +528,14 @@ func (c *Controller) logStderr() {
 // processEvent updates internal state based on events
 func (c *Controller) processEvent(event TelemetryEvent) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	
 	switch event.Event {
 	case EventStatus:
 		c.state = event.State
 		c.recording = (event.State == "recording")
-		
+
 	case EventLevel:
 		c.lastLevel = event.DB
-		
+
 	case EventSession:
 		if event.Action == "start" {
 			c.sessionID = event.ID
@@ -202,6 +543,93 @@ func (c *Controller) processEvent(event TelemetryEvent) {
 			c.sessionID = ""
 		}
 	}
+	c.mu.Unlock()
+
+	if event.Event == EventLevel {
+		c.pushLevelSample(event)
+	}
+}
+
+// pushLevelSample appends a level reading to the rolling history and
+// schedules a coalesced notification to OnLevelHistory handlers.
+func (c *Controller) pushLevelSample(event TelemetryEvent) {
+	c.levelHistoryMu.Lock()
+	c.levelHistory = append(c.levelHistory, LevelSample{Ts: sampleTimestamp(event), DB: event.DB})
+	if len(c.levelHistory) > c.levelHistorySize {
+		c.levelHistory = c.levelHistory[len(c.levelHistory)-c.levelHistorySize:]
+	}
+	c.levelHistoryMu.Unlock()
+
+	c.notifyLevelHistory()
+}
+
+// sampleTimestamp picks the best available timestamp for an event,
+// falling back to wall-clock time if the harness didn't supply one.
+func sampleTimestamp(event TelemetryEvent) int64 {
+	if event.Timestamp != 0 {
+		return event.Timestamp
+	}
+	if event.Time != 0 {
+		return event.Time
+	}
+	return time.Now().UnixMilli()
+}
+
+// notifyLevelHistory fires OnLevelHistory handlers, coalescing bursts of
+// updates into at most one notification per levelHistoryCoalesceWindow.
+func (c *Controller) notifyLevelHistory() {
+	c.levelHistoryNotifyMu.Lock()
+	defer c.levelHistoryNotifyMu.Unlock()
+
+	if c.levelHistoryPending {
+		return
+	}
+
+	elapsed := time.Since(c.levelHistoryLastNotify)
+	if elapsed >= levelHistoryCoalesceWindow {
+		c.levelHistoryLastNotify = time.Now()
+		go c.emitLevelHistory()
+		return
+	}
+
+	c.levelHistoryPending = true
+	time.AfterFunc(levelHistoryCoalesceWindow-elapsed, func() {
+		c.levelHistoryNotifyMu.Lock()
+		c.levelHistoryPending = false
+		c.levelHistoryLastNotify = time.Now()
+		c.levelHistoryNotifyMu.Unlock()
+		c.emitLevelHistory()
+	})
+}
+
+// emitLevelHistory notifies all registered LevelHistoryHandlers with a
+// snapshot of the current rolling history.
+func (c *Controller) emitLevelHistory() {
+	history := c.LevelHistory()
+
+	c.levelHistoryHandlersMu.RLock()
+	defer c.levelHistoryHandlersMu.RUnlock()
+	for _, handler := range c.levelHistoryHandlers {
+		handler(history)
+	}
+}
+
+// LevelHistory returns a snapshot of the rolling dB level history.
+func (c *Controller) LevelHistory() []LevelSample {
+	c.levelHistoryMu.Lock()
+	defer c.levelHistoryMu.Unlock()
+
+	history := make([]LevelSample, len(c.levelHistory))
+	copy(history, c.levelHistory)
+	return history
+}
+
+// OnLevelHistory registers a handler that is notified, at most every
+// 50ms, with the current rolling level history.
+func (c *Controller) OnLevelHistory(handler LevelHistoryHandler) {
+	c.levelHistoryHandlersMu.Lock()
+	defer c.levelHistoryHandlersMu.Unlock()
+	c.levelHistoryHandlers = append(c.levelHistoryHandlers, handler)
 }
 
 // sendCommand sends a command to the harness
@@ -1,7 +1,15 @@
 package ipc
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewController(t *testing.T) {
@@ -83,3 +91,288 @@ func TestSessionTracking(t *testing.T) {
 		t.Errorf("Expected empty session ID after end, got '%s'", c.SessionID())
 	}
 }
+
+func TestWatchdogTimeoutDispatchesError(t *testing.T) {
+	c := NewController("/path/to/harness")
+	c.SetHeartbeatTimeout(20 * time.Millisecond)
+
+	errCh := make(chan TelemetryEvent, 1)
+	c.OnEvent(func(event TelemetryEvent) {
+		if event.Event == EventError {
+			select {
+			case errCh <- event:
+			default:
+			}
+		}
+	})
+
+	go c.watchdog()
+	defer c.Terminate()
+
+	select {
+	case event := <-errCh:
+		if event.Body == "" {
+			t.Error("expected a non-empty error body")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not report a timeout")
+	}
+
+	if c.Healthy() {
+		t.Error("expected Healthy() to be false after a watchdog timeout")
+	}
+}
+
+func TestWatchdogResetPreventsTimeout(t *testing.T) {
+	c := NewController("/path/to/harness")
+	c.SetHeartbeatTimeout(50 * time.Millisecond)
+
+	fired := make(chan struct{}, 1)
+	c.OnEvent(func(event TelemetryEvent) {
+		if event.Event == EventError {
+			select {
+			case fired <- struct{}{}:
+			default:
+			}
+		}
+	})
+
+	go c.watchdog()
+	defer c.Terminate()
+
+	deadline := time.After(150 * time.Millisecond)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			c.resetWatchdog()
+		}
+	}
+
+	select {
+	case <-fired:
+		t.Error("expected no watchdog timeout while heartbeats kept arriving")
+	default:
+	}
+}
+
+func TestLevelHistoryTracksSamplesAndTrims(t *testing.T) {
+	c := NewController("/path/to/harness")
+	c.SetLevelHistorySize(3)
+
+	for i, db := range []float64{-10, -9, -8, -7} {
+		c.processEvent(TelemetryEvent{Event: EventLevel, DB: db, Time: int64(i)})
+	}
+
+	history := c.LevelHistory()
+	if len(history) != 3 {
+		t.Fatalf("expected history trimmed to 3 samples, got %d", len(history))
+	}
+	if history[len(history)-1].DB != -7 {
+		t.Errorf("expected most recent sample to be -7, got %v", history[len(history)-1].DB)
+	}
+}
+
+func TestOnLevelHistoryNotifiesHandler(t *testing.T) {
+	c := NewController("/path/to/harness")
+
+	notified := make(chan []LevelSample, 1)
+	c.OnLevelHistory(func(history []LevelSample) {
+		select {
+		case notified <- history:
+		default:
+		}
+	})
+
+	c.processEvent(TelemetryEvent{Event: EventLevel, DB: -12})
+
+	select {
+	case history := <-notified:
+		if len(history) != 1 || history[0].DB != -12 {
+			t.Errorf("expected one sample of -12dB, got %+v", history)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnLevelHistory handler was not notified")
+	}
+}
+
+func TestListenLegacyHandlesLargeTranscript(t *testing.T) {
+	c := NewController("/path/to/harness")
+
+	body := strings.Repeat("x", 2*1024*1024)
+	line, err := json.Marshal(TelemetryEvent{Event: EventText, Body: body})
+	if err != nil {
+		t.Fatalf("failed to marshal fixture event: %v", err)
+	}
+
+	var got TelemetryEvent
+	c.OnEvent(func(event TelemetryEvent) { got = event })
+
+	c.listenLegacy(bufio.NewReader(bytes.NewReader(append(line, '\n'))))
+
+	if len(got.Body) != len(body) {
+		t.Errorf("expected a %d-byte body, got %d", len(body), len(got.Body))
+	}
+}
+
+func TestListenFramedReassemblesChunkedStream(t *testing.T) {
+	c := NewController("/path/to/harness")
+
+	body := strings.Repeat("y", 2*1024*1024)
+	half := len(body) / 2
+
+	var buf bytes.Buffer
+	writeFrame(t, &buf, wireFrame{
+		TelemetryEvent: TelemetryEvent{Event: EventText, Body: body[:half]},
+		StreamID:       "stream-1",
+	})
+	writeFrame(t, &buf, wireFrame{
+		TelemetryEvent: TelemetryEvent{Event: EventText, Body: body[half:]},
+		StreamID:       "stream-1",
+		Final:          true,
+	})
+
+	var got TelemetryEvent
+	c.OnEvent(func(event TelemetryEvent) { got = event })
+
+	c.listenFramed(bufio.NewReader(&buf))
+
+	if got.Body != body {
+		t.Errorf("expected reassembled body of length %d, got %d", len(body), len(got.Body))
+	}
+}
+
+func TestListenFramedBoundsCumulativeStreamSize(t *testing.T) {
+	c := NewController("/path/to/harness")
+
+	chunk := strings.Repeat("z", 1024*1024)
+
+	var buf bytes.Buffer
+	for i := 0; i < 5; i++ {
+		writeFrame(t, &buf, wireFrame{
+			TelemetryEvent: TelemetryEvent{Event: EventText, Body: chunk},
+			StreamID:       "stream-1",
+			Final:          i == 4,
+		})
+	}
+
+	dispatched := false
+	c.OnEvent(func(event TelemetryEvent) { dispatched = true })
+
+	c.listenFramed(bufio.NewReader(&buf))
+
+	if dispatched {
+		t.Error("expected a stream exceeding the cumulative size cap to be dropped, not dispatched")
+	}
+}
+
+func writeFrame(t *testing.T, buf *bytes.Buffer, frame wireFrame) {
+	t.Helper()
+
+	data, err := json.Marshal(frame)
+	if err != nil {
+		t.Fatalf("failed to marshal frame: %v", err)
+	}
+
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(data)))
+	buf.Write(lenBuf[:])
+	buf.Write(data)
+}
+
+// TestHandleWatchdogTimeoutRelaunchesWithBackoff drives the watchdog's
+// relaunch loop against a real subprocess: the first restart attempt is
+// made to fail (the harness binary is briefly missing) so the second
+// attempt has to wait out a longer backoff before it can succeed,
+// proving the delay between attempts actually grows rather than just
+// checking backoffDelay in isolation.
+func TestHandleWatchdogTimeoutRelaunchesWithBackoff(t *testing.T) {
+	tmpDir := t.TempDir()
+	binPath := filepath.Join(tmpDir, "harness")
+	if err := writeFakeHarness(binPath); err != nil {
+		t.Fatalf("failed to write fake harness: %v", err)
+	}
+
+	c := NewController(binPath)
+	c.SetHeartbeatTimeout(20 * time.Millisecond)
+	c.SetAutoRestart(true)
+
+	type statusAt struct {
+		state string
+		at    time.Time
+	}
+	statusCh := make(chan statusAt, 8)
+	c.OnEvent(func(event TelemetryEvent) {
+		if event.Event == EventStatus {
+			select {
+			case statusCh <- statusAt{event.State, time.Now()}:
+			default:
+			}
+		}
+	})
+
+	if err := c.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer c.Terminate()
+
+	var reconnectAt time.Time
+	select {
+	case s := <-statusCh:
+		if s.state != "reconnecting" {
+			t.Fatalf("expected first status 'reconnecting', got %q", s.state)
+		}
+		reconnectAt = s.at
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not report 'reconnecting' after the heartbeat timeout")
+	}
+
+	// Pull the binary out from under the first restart attempt so it
+	// fails, then put it back in time for the second, longer-backoff
+	// attempt to succeed.
+	os.Remove(binPath)
+	time.AfterFunc(700*time.Millisecond, func() { writeFakeHarness(binPath) })
+
+	select {
+	case s := <-statusCh:
+		if s.state != "ready" {
+			t.Fatalf("expected status 'ready' after relaunch, got %q", s.state)
+		}
+		if elapsed := s.at.Sub(reconnectAt); elapsed < 1400*time.Millisecond {
+			t.Errorf("expected relaunch to span a growing backoff (>=1.4s since reconnecting), took %v", elapsed)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("watchdog never reported 'ready' after the relaunch succeeded")
+	}
+}
+
+// writeFakeHarness installs a stand-in "harness" at path: a shell script
+// that just sleeps, so the controller can launch it without ever
+// satisfying a heartbeat.
+func writeFakeHarness(path string) error {
+	return os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 5\n"), 0755)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 500 * time.Millisecond},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, maxBackoff},
+	}
+
+	for _, test := range tests {
+		if got := backoffDelay(test.attempt); got != test.want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", test.attempt, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Package search indexes lecture transcripts for full-text search.
+//
+// A Scanner walks each session's transcript file, splits it into
+// paragraphs, and feeds them into a bleve index. It tracks each
+// transcript's size and modification time so re-scanning an unchanged
+// session is a cheap no-op, making it safe to run repeatedly - on a
+// timer, on startup, or after every edit - the same way a music
+// library scanner avoids re-reading files it has already seen.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	bsearch "github.com/blevesearch/bleve/v2/search"
+)
+
+// indexDirName and stateFileName are the Scanner's on-disk artifacts,
+// relative to the data directory it is opened with.
+const (
+	indexDirName  = "search.bleve"
+	stateFileName = "search_state.json"
+)
+
+// timestampPattern matches an optional "[HH:MM:SS]" marker at the start
+// of a paragraph.
+var timestampPattern = regexp.MustCompile(`^\[(\d{1,2}):(\d{2}):(\d{2})\]\s*`)
+
+// blankLinePattern splits a transcript into paragraphs on blank lines.
+var blankLinePattern = regexp.MustCompile(`\n\s*\n`)
+
+// SessionRef is the minimal session information ScanSession needs. It
+// is defined here, rather than accepted as a *session.Session, so this
+// package has no dependency on package session - session.Manager is the
+// one that depends on search, and a cycle would follow otherwise.
+type SessionRef struct {
+	ID             string
+	Course         string
+	TranscriptFile string
+}
+
+// Hit is a single transcript search result.
+type Hit struct {
+	SessionID string
+	Snippet   string
+	Offset    int
+	Timestamp time.Duration
+	Score     float64
+}
+
+// scanState records what was indexed for a session the last time it was
+// scanned, so ScanSession can tell whether the transcript has changed.
+type scanState struct {
+	ModTime    time.Time `json:"mod_time"`
+	Size       int64     `json:"size"`
+	Paragraphs int       `json:"paragraphs"`
+}
+
+// indexedParagraph is the document shape stored in the bleve index.
+type indexedParagraph struct {
+	SessionID        string  `json:"session_id"`
+	Course           string  `json:"course"`
+	Offset           int     `json:"offset"`
+	TimestampSeconds float64 `json:"timestamp_seconds"`
+	Text             string  `json:"text"`
+}
+
+// Scanner indexes session transcripts for full-text search.
+type Scanner struct {
+	mu        sync.Mutex
+	index     bleve.Index
+	statePath string
+	state     map[string]scanState
+}
+
+// NewScanner opens (or creates) the search index under dataDir.
+func NewScanner(dataDir string) (*Scanner, error) {
+	indexPath := filepath.Join(dataDir, indexDirName)
+
+	index, err := bleve.Open(indexPath)
+	if err == bleve.ErrorIndexPathDoesNotExist {
+		index, err = bleve.New(indexPath, bleve.NewIndexMapping())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search index: %w", err)
+	}
+
+	s := &Scanner{
+		index:     index,
+		statePath: filepath.Join(dataDir, stateFileName),
+		state:     make(map[string]scanState),
+	}
+	s.loadState()
+
+	return s, nil
+}
+
+// Close flushes and closes the underlying index.
+func (s *Scanner) Close() error {
+	return s.index.Close()
+}
+
+// ScanAll scans every session in refs, logging and continuing past any
+// individual failure so one unreadable transcript doesn't stop the rest
+// of the sweep.
+func (s *Scanner) ScanAll(refs []SessionRef) {
+	for _, ref := range refs {
+		if err := s.ScanSession(ref.ID, ref.Course, ref.TranscriptFile); err != nil {
+			log.Printf("failed to index session %s: %v", ref.ID, err)
+		}
+	}
+}
+
+// ScanSession (re)indexes a single session's transcript. It is a no-op
+// if the transcript is unchanged since the last scan, and tolerates a
+// transcript that doesn't exist yet, which is the normal state for a
+// session that is still being recorded.
+func (s *Scanner) ScanSession(sessionID, course, transcriptPath string) error {
+	info, err := os.Stat(transcriptPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat transcript: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, seen := s.state[sessionID]
+	if seen && prev.ModTime.Equal(info.ModTime()) && prev.Size == info.Size() {
+		return nil
+	}
+
+	data, err := os.ReadFile(transcriptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read transcript: %w", err)
+	}
+	paragraphs := splitParagraphs(data)
+
+	batch := s.index.NewBatch()
+	for i := 0; i < prev.Paragraphs; i++ {
+		batch.Delete(paragraphID(sessionID, i))
+	}
+	for i, p := range paragraphs {
+		doc := indexedParagraph{
+			SessionID:        sessionID,
+			Course:           course,
+			Offset:           p.Offset,
+			TimestampSeconds: p.Timestamp.Seconds(),
+			Text:             p.Text,
+		}
+		if err := batch.Index(paragraphID(sessionID, i), doc); err != nil {
+			return fmt.Errorf("failed to index paragraph %d of %s: %w", i, sessionID, err)
+		}
+	}
+
+	if err := s.index.Batch(batch); err != nil {
+		return fmt.Errorf("failed to apply search index batch for %s: %w", sessionID, err)
+	}
+
+	s.state[sessionID] = scanState{ModTime: info.ModTime(), Size: info.Size(), Paragraphs: len(paragraphs)}
+	s.saveState()
+
+	return nil
+}
+
+// Search runs a full-text query over indexed transcripts, optionally
+// restricted to a single course, and returns the matching paragraphs
+// ranked by relevance.
+func (s *Scanner) Search(q, course string, limit int) ([]Hit, error) {
+	textQuery := bleve.NewQueryStringQuery(q)
+
+	req := bleve.NewSearchRequestOptions(textQuery, limit, 0, false)
+	if course != "" {
+		courseQuery := bleve.NewMatchQuery(course)
+		courseQuery.SetField("course")
+		req = bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(textQuery, courseQuery), limit, 0, false)
+	}
+	req.Fields = []string{"session_id", "offset", "timestamp_seconds"}
+	req.Highlight = bleve.NewHighlight()
+
+	result, err := s.index.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	hits := make([]Hit, 0, len(result.Hits))
+	for _, match := range result.Hits {
+		hits = append(hits, hitFromMatch(match))
+	}
+	return hits, nil
+}
+
+// hitFromMatch converts a bleve match, with its highlighted fragments,
+// into a Hit.
+func hitFromMatch(match *bsearch.DocumentMatch) Hit {
+	sessionID, _ := match.Fields["session_id"].(string)
+	offset, _ := match.Fields["offset"].(float64)
+	timestampSeconds, _ := match.Fields["timestamp_seconds"].(float64)
+
+	var snippet string
+	if fragments, ok := match.Fragments["text"]; ok && len(fragments) > 0 {
+		snippet = fragments[0]
+	}
+
+	return Hit{
+		SessionID: sessionID,
+		Snippet:   snippet,
+		Offset:    int(offset),
+		Timestamp: time.Duration(timestampSeconds * float64(time.Second)),
+		Score:     match.Score,
+	}
+}
+
+// paragraph is one unit of indexable transcript text.
+type paragraph struct {
+	Offset    int
+	Timestamp time.Duration
+	Text      string
+}
+
+// splitParagraphs splits a transcript into paragraphs on blank lines. A
+// paragraph beginning with a "[HH:MM:SS]" marker has that prefix parsed
+// into a timestamp and stripped from its text; otherwise the timestamp
+// is left zero and callers fall back to the byte offset.
+func splitParagraphs(data []byte) []paragraph {
+	text := string(data)
+
+	var paragraphs []paragraph
+	start := 0
+
+	// blankLinePattern matches separators of varying length (multiple
+	// blank lines, trailing spaces), so the next paragraph's start has
+	// to come from the match's actual end index rather than an assumed
+	// separator width.
+	separators := blankLinePattern.FindAllStringIndex(text, -1)
+	for i := 0; i <= len(separators); i++ {
+		end := len(text)
+		nextStart := end
+		if i < len(separators) {
+			end = separators[i][0]
+			nextStart = separators[i][1]
+		}
+
+		raw := text[start:end]
+		paragraphStart := start
+		start = nextStart
+
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			continue
+		}
+
+		ts := time.Duration(0)
+		if loc := timestampPattern.FindStringSubmatchIndex(trimmed); loc != nil {
+			h, _ := strconv.Atoi(trimmed[loc[2]:loc[3]])
+			m, _ := strconv.Atoi(trimmed[loc[4]:loc[5]])
+			sec, _ := strconv.Atoi(trimmed[loc[6]:loc[7]])
+			ts = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second
+			trimmed = strings.TrimSpace(trimmed[loc[1]:])
+		}
+
+		paragraphs = append(paragraphs, paragraph{Offset: paragraphStart, Timestamp: ts, Text: trimmed})
+	}
+
+	return paragraphs
+}
+
+// paragraphID is the bleve document ID for a session's nth paragraph.
+func paragraphID(sessionID string, index int) string {
+	return fmt.Sprintf("%s#%d", sessionID, index)
+}
+
+// loadState reads previously persisted scan state, if any.
+func (s *Scanner) loadState() {
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		log.Printf("failed to parse search scan state: %v", err)
+	}
+}
+
+// saveState persists scan state so a restart doesn't re-index every
+// unchanged transcript.
+func (s *Scanner) saveState() {
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		log.Printf("failed to marshal search scan state: %v", err)
+		return
+	}
+	if err := os.WriteFile(s.statePath, data, 0644); err != nil {
+		log.Printf("failed to save search scan state: %v", err)
+	}
+}
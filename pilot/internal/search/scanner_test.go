@@ -0,0 +1,33 @@
+package search
+
+import "testing"
+
+func TestSplitParagraphsOffsetsSurviveIrregularGaps(t *testing.T) {
+	// A 3-blank-line gap between the first two paragraphs, and a normal
+	// single-blank-line gap before the third, so a hardcoded 2-byte
+	// separator width would drift every offset after the first gap.
+	text := "[00:00:01] first paragraph\n\n\n\nsecond paragraph\n\nthird paragraph"
+
+	paragraphs := splitParagraphs([]byte(text))
+	if len(paragraphs) != 3 {
+		t.Fatalf("expected 3 paragraphs, got %d", len(paragraphs))
+	}
+
+	if paragraphs[0].Offset != 0 {
+		t.Errorf("expected first paragraph offset 0, got %d", paragraphs[0].Offset)
+	}
+
+	if paragraphs[1].Text != "second paragraph" {
+		t.Errorf("expected second paragraph text %q, got %q", "second paragraph", paragraphs[1].Text)
+	}
+	if got, want := paragraphs[1].Offset, len("[00:00:01] first paragraph\n\n\n\n"); got != want {
+		t.Errorf("expected second paragraph offset %d, got %d", want, got)
+	}
+
+	if paragraphs[2].Text != "third paragraph" {
+		t.Errorf("expected third paragraph text %q, got %q", "third paragraph", paragraphs[2].Text)
+	}
+	if got, want := paragraphs[2].Offset, len("[00:00:01] first paragraph\n\n\n\nsecond paragraph\n\n"); got != want {
+		t.Errorf("expected third paragraph offset %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,232 @@
+// Package serve exposes a user's library read-only over HTTP, so a
+// phone or tablet on the same LAN can browse and replay lectures
+// without copying files out of the Pilot's config directory.
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grandcat/zeroconf"
+
+	"github.com/topnotchnotes/pilot/internal/auth"
+	"github.com/topnotchnotes/pilot/internal/session"
+)
+
+// serviceType is the mDNS service type the server advertises itself
+// under, so clients can find it with a plain Bonjour/Avahi browse.
+const serviceType = "_topnotch._tcp"
+
+// Server serves a session.Library read-only over HTTP, gated by an
+// auth.Store, and advertises itself on the LAN via mDNS.
+type Server struct {
+	library  session.Library
+	username string
+	users    *auth.Store
+	addr     string
+
+	httpServer *http.Server
+	mdns       *zeroconf.Server
+}
+
+// NewServer creates a Server for username's library, listening on addr
+// (e.g. ":8420"). Requests are authenticated against users, but only
+// username's own credentials are accepted - library is that one user's
+// data, and every other local account's library stays off this server
+// even though it shares the same user store.
+func NewServer(library session.Library, username string, users *auth.Store, addr string) *Server {
+	s := &Server{library: library, username: username, users: users, addr: addr}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/courses", s.requireAuth(s.handleCourses))
+	mux.HandleFunc("/api/courses/", s.requireAuth(s.handleCourseSessions))
+	mux.HandleFunc("/api/sessions/", s.requireAuth(s.handleSessionRoutes))
+	mux.HandleFunc("/api/search", s.requireAuth(s.handleSearch))
+
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+
+	return s
+}
+
+// Start begins serving HTTP in the background and, if advertise is
+// true, announces the server on the LAN via mDNS.
+func (s *Server) Start(advertise bool) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	if advertise {
+		_, portStr, err := net.SplitHostPort(ln.Addr().String())
+		if err != nil {
+			return fmt.Errorf("failed to determine port for mDNS advertisement: %w", err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse listen port: %w", err)
+		}
+
+		mdnsServer, err := zeroconf.Register("Pilot", serviceType, "local.", port, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to advertise mDNS service: %w", err)
+		}
+		s.mdns = mdnsServer
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Printf("serve: http server error: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server and withdraws the mDNS
+// advertisement, if one was made.
+func (s *Server) Stop(ctx context.Context) error {
+	if s.mdns != nil {
+		s.mdns.Shutdown()
+	}
+	return s.httpServer.Shutdown(ctx)
+}
+
+// requireAuth wraps handler with HTTP Basic Auth checked against the
+// user store, so the network API shares credentials with the Fyne
+// dashboard's login window. Only s.username's own credentials are
+// accepted - library is scoped to that one user, so any other valid
+// local account must be rejected rather than handed someone else's
+// recordings.
+func (s *Server) requireAuth(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Pilot"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		if _, ok := s.users.Verify(username, password); !ok || username != s.username {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Pilot"`)
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleCourses(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.library.ListCourses())
+}
+
+// handleCourseSessions serves GET /api/courses/{id}/sessions.
+func (s *Server) handleCourseSessions(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/courses/"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "sessions" {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, s.library.ListSessions(parts[0]))
+}
+
+// handleSessionRoutes dispatches GET /api/sessions/{id}[/audio|/transcript].
+func (s *Server) handleSessionRoutes(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/sessions/"), "/", 2)
+	if parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		s.handleSession(w, r, parts[0])
+	case parts[1] == "audio":
+		s.handleSessionAudio(w, r, parts[0])
+	case parts[1] == "transcript":
+		s.handleSessionTranscript(w, r, parts[0])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request, id string) {
+	sess, ok := s.library.GetSession(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, sess)
+}
+
+// handleSessionAudio streams a session's WAV file, supporting byte
+// range requests so a client can seek or resume playback.
+func (s *Server) handleSessionAudio(w http.ResponseWriter, r *http.Request, id string) {
+	sess, ok := s.library.GetSession(id)
+	if !ok || sess.AudioFile == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(sess.AudioFile)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "failed to stat audio file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "audio/wav")
+	http.ServeContent(w, r, filepath.Base(sess.AudioFile), info.ModTime(), f)
+}
+
+func (s *Server) handleSessionTranscript(w http.ResponseWriter, r *http.Request, id string) {
+	sess, ok := s.library.GetSession(id)
+	if !ok || sess.TranscriptFile == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := os.ReadFile(sess.TranscriptFile)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Write(data)
+}
+
+// handleSearch serves GET /api/search?q=...&course=..., wired to the
+// full-text index.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "missing query parameter q", http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, s.library.Search(query, r.URL.Query().Get("course")))
+}
+
+// writeJSON encodes v as the response body.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("serve: failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/topnotchnotes/pilot/internal/auth"
+	"github.com/topnotchnotes/pilot/internal/session"
+)
+
+func TestRequireAuthRejectsOtherValidUsers(t *testing.T) {
+	dataDir := t.TempDir()
+
+	users, _, err := auth.NewStore(dataDir)
+	if err != nil {
+		t.Fatalf("failed to open user store: %v", err)
+	}
+	if err := users.CreateUser("otheruser", "otherpassword", false); err != nil {
+		t.Fatalf("failed to create second user: %v", err)
+	}
+
+	library := session.NewManager(dataDir, "admin")
+	defer library.Close()
+
+	s := NewServer(library, "admin", users, ":0")
+
+	handlerCalled := false
+	protected := s.requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/courses", nil)
+	req.SetBasicAuth("otheruser", "otherpassword")
+	rec := httptest.NewRecorder()
+
+	protected(rec, req)
+
+	if handlerCalled {
+		t.Error("expected requireAuth to reject a valid-but-different user's credentials")
+	}
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestRequireAuthAcceptsTheServersOwnUser(t *testing.T) {
+	dataDir := t.TempDir()
+
+	users, bootstrapPassword, err := auth.NewStore(dataDir)
+	if err != nil {
+		t.Fatalf("failed to open user store: %v", err)
+	}
+
+	library := session.NewManager(dataDir, "admin")
+	defer library.Close()
+
+	s := NewServer(library, "admin", users, ":0")
+
+	handlerCalled := false
+	protected := s.requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/courses", nil)
+	req.SetBasicAuth("admin", bootstrapPassword)
+	rec := httptest.NewRecorder()
+
+	protected(rec, req)
+
+	if !handlerCalled {
+		t.Error("expected requireAuth to accept the server's own user")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
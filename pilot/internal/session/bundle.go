@@ -0,0 +1,260 @@
+package session
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// bundleSchema is the current .tnn manifest schema version. Bump this
+// whenever the bundle layout changes in a way importers must know about.
+const bundleSchema = 1
+
+// bundleToolVersion is recorded in every exported bundle's manifest.
+const bundleToolVersion = "pilot-1.0.0"
+
+// bundleManifest describes the contents of a .tnn session bundle: the
+// session metadata plus a SHA-256 checksum for every payload file, so
+// ImportSession can detect a truncated or corrupted archive before it
+// touches disk.
+type bundleManifest struct {
+	Schema    int               `json:"schema"`
+	CreatedBy string            `json:"created_by"`
+	Session   *Session          `json:"session"`
+	Files     map[string]string `json:"files"`
+}
+
+// bundleFile is a single payload destined for the archive.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// ExportSession writes session id as a single-file .tnn bundle (a
+// tar+zstd archive) at dstPath: a manifest, the transcript, notes, the
+// rolling level history if one was saved, and the original audio file
+// if present.
+func (m *Manager) ExportSession(id, dstPath string) error {
+	sess, ok := m.GetSession(id)
+	if !ok {
+		return fmt.Errorf("session not found: %s", id)
+	}
+
+	var files []bundleFile
+
+	if data, err := os.ReadFile(sess.TranscriptFile); err == nil {
+		files = append(files, bundleFile{"transcript.txt", data})
+	}
+
+	files = append(files, bundleFile{"notes.md", []byte(sess.Notes)})
+
+	levelsPath := filepath.Join(m.GetSessionDir(sess.Course, sess.ID), sess.ID+"_levels.jsonl")
+	if data, err := os.ReadFile(levelsPath); err == nil {
+		files = append(files, bundleFile{"levels.jsonl", data})
+	}
+
+	if sess.AudioFile != "" {
+		if data, err := os.ReadFile(sess.AudioFile); err == nil {
+			files = append(files, bundleFile{filepath.Base(sess.AudioFile), data})
+		}
+	}
+
+	manifest := &bundleManifest{
+		Schema:    bundleSchema,
+		CreatedBy: bundleToolVersion,
+		Session:   sess,
+		Files:     make(map[string]string, len(files)),
+	}
+	for _, file := range files {
+		manifest.Files[file.name] = sha256Hex(file.data)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+
+	f, err := os.Create(dstPath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	defer f.Close()
+
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		return fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+	defer zw.Close()
+
+	tw := tar.NewWriter(zw)
+	defer tw.Close()
+
+	if err := writeTarEntry(tw, "manifest.json", manifestData); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeTarEntry(tw, file.name, file.data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportSession reads a .tnn bundle written by ExportSession, verifies
+// every payload against the manifest's checksums, and adds it as a new
+// session under a freshly-sanitized ID so importing the same bundle
+// twice never clobbers an existing session.
+func (m *Manager) ImportSession(srcPath string) (*Session, error) {
+	files, manifest, err := readBundle(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	id := sanitizeID(manifest.Session.ID)
+	if id == "" {
+		id = "import"
+	}
+	for {
+		if _, exists := m.GetSession(id); !exists {
+			break
+		}
+		id = sanitizeID(id) + "_import"
+	}
+
+	sess := *manifest.Session
+	sess.ID = id
+
+	if _, ok := m.GetCourse(sess.Course); !ok {
+		sess.Course = ""
+	}
+
+	sessDir := m.GetSessionDir(sess.Course, id)
+	if err := os.MkdirAll(sessDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	if data, ok := files["transcript.txt"]; ok {
+		sess.TranscriptFile = filepath.Join(sessDir, id+".md")
+		if err := os.WriteFile(sess.TranscriptFile, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write transcript: %w", err)
+		}
+	}
+
+	if data, ok := files["notes.md"]; ok {
+		sess.Notes = string(data)
+	}
+
+	if data, ok := files["levels.jsonl"]; ok {
+		if err := os.WriteFile(filepath.Join(sessDir, id+"_levels.jsonl"), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write level history: %w", err)
+		}
+	}
+
+	if manifest.Session.AudioFile != "" {
+		audioName := filepath.Base(manifest.Session.AudioFile)
+		if data, ok := files[audioName]; ok {
+			sess.AudioFile = filepath.Join(sessDir, id+filepath.Ext(audioName))
+			if err := os.WriteFile(sess.AudioFile, data, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write audio: %w", err)
+			}
+		}
+	}
+
+	if err := m.UpdateSession(&sess); err != nil {
+		return nil, err
+	}
+
+	return &sess, nil
+}
+
+// readBundle extracts every entry from a .tnn bundle and verifies each
+// payload's checksum against the manifest before returning.
+func readBundle(srcPath string) (map[string][]byte, *bundleManifest, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+	defer zr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle entry: %w", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle entry %s: %w", hdr.Name, err)
+		}
+		files[hdr.Name] = data
+	}
+
+	manifestData, ok := files["manifest.json"]
+	if !ok {
+		return nil, nil, fmt.Errorf("bundle is missing manifest.json")
+	}
+
+	var manifest bundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	if manifest.Schema != bundleSchema {
+		return nil, nil, fmt.Errorf("unsupported bundle schema: %d", manifest.Schema)
+	}
+	if manifest.Session == nil {
+		return nil, nil, fmt.Errorf("bundle manifest is missing session metadata")
+	}
+
+	for name, wantSum := range manifest.Files {
+		data, ok := files[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("bundle is missing file %q referenced by its manifest", name)
+		}
+		if gotSum := sha256Hex(data); gotSum != wantSum {
+			return nil, nil, fmt.Errorf("checksum mismatch for %q", name)
+		}
+	}
+
+	return files, &manifest, nil
+}
+
+// writeTarEntry writes a single regular file entry to a tar archive.
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write tar header for %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write tar entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportImportSessionRoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	src := NewManager(srcDir, "testuser")
+
+	course, err := src.CreateCourse("Test Course", "TC100", 2024, "Spring")
+	if err != nil {
+		t.Fatalf("CreateCourse failed: %v", err)
+	}
+
+	sess, err := src.CreateSession(course.ID, "Lecture 1")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	if err := os.WriteFile(sess.TranscriptFile, []byte("hello world"), 0644); err != nil {
+		t.Fatalf("failed to write transcript fixture: %v", err)
+	}
+	sess.Notes = "some notes"
+	if err := src.UpdateSession(sess); err != nil {
+		t.Fatalf("UpdateSession failed: %v", err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "lecture.tnn")
+	if err := src.ExportSession(sess.ID, bundlePath); err != nil {
+		t.Fatalf("ExportSession failed: %v", err)
+	}
+
+	dstDir := t.TempDir()
+	dst := NewManager(dstDir, "testuser")
+
+	imported, err := dst.ImportSession(bundlePath)
+	if err != nil {
+		t.Fatalf("ImportSession failed: %v", err)
+	}
+
+	if imported.Notes != "some notes" {
+		t.Errorf("expected imported notes 'some notes', got %q", imported.Notes)
+	}
+
+	data, err := os.ReadFile(imported.TranscriptFile)
+	if err != nil {
+		t.Fatalf("failed to read imported transcript: %v", err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("expected imported transcript 'hello world', got %q", data)
+	}
+
+	// Importing the same bundle again must not clobber the first import.
+	imported2, err := dst.ImportSession(bundlePath)
+	if err != nil {
+		t.Fatalf("second ImportSession failed: %v", err)
+	}
+	if imported2.ID == imported.ID {
+		t.Errorf("expected a distinct session ID on re-import, got %q twice", imported.ID)
+	}
+}
@@ -0,0 +1,17 @@
+package session
+
+import "github.com/topnotchnotes/pilot/internal/search"
+
+// Library is the read-only subset of Manager's API. Packages that only
+// need to browse a user's recordings - like internal/serve - depend on
+// Library instead of *Manager, so they can't accidentally write to the
+// store and don't pull in anything beyond what they need.
+type Library interface {
+	ListCourses() []*Course
+	GetCourse(id string) (*Course, bool)
+	ListSessions(courseID string) []*Session
+	GetSession(id string) (*Session, bool)
+	Search(query, courseID string) []search.Hit
+}
+
+var _ Library = (*Manager)(nil)
@@ -5,24 +5,29 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/tidwall/buntdb"
+	"github.com/tidwall/gjson"
+
+	"github.com/topnotchnotes/pilot/internal/search"
 )
 
 // Session represents a recording session
 type Session struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Course      string    `json:"course"`
-	CreatedAt   time.Time `json:"created_at"`
-	Duration    int64     `json:"duration_seconds"`
-	AudioFile   string    `json:"audio_file"`
-	TranscriptFile string `json:"transcript_file"`
-	Notes       string    `json:"notes"`
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Course         string    `json:"course"`
+	CreatedAt      time.Time `json:"created_at"`
+	Duration       int64     `json:"duration_seconds"`
+	AudioFile      string    `json:"audio_file"`
+	TranscriptFile string    `json:"transcript_file"`
+	Notes          string    `json:"notes"`
 }
 
 // Course represents an academic course
@@ -36,33 +41,133 @@ type Course struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
-// Manager handles session and course organization
+// dbFileName is the embedded store's file, relative to a Manager's data
+// directory.
+const dbFileName = "pilot.db"
+
+// legacyBootstrapUser is the user whose per-user store is seeded from
+// data written before multi-user support existed - the same bootstrap
+// "admin" account auth.NewStore creates when there are no users yet, and
+// the only account that could have been writing to the shared top-level
+// data directory beforehand.
+const legacyBootstrapUser = "admin"
+
+// Secondary index names registered on the store. Queries filtered or
+// ordered by one of these fields are index range scans rather than
+// full-table loads.
+const (
+	sessionsByCourseIndex      = "sessions_by_course"
+	sessionsByCreatedAtIndex   = "sessions_by_created_at"
+	coursesByYearSemesterIndex = "courses_by_year_semester"
+	readlistsByCreatedAtIndex  = "readlists_by_created_at"
+)
+
+// Manager handles session and course organization. Persistence goes
+// through an embedded buntdb store instead of rewriting courses.json/
+// sessions.json on every mutation.
 type Manager struct {
-	dataDir  string
-	sessions map[string]*Session
-	courses  map[string]*Course
-	mu       sync.RWMutex
+	dataDir string
+	db      *buntdb.DB
+	scanner *search.Scanner
 }
 
-// NewManager creates a new session manager
-func NewManager(dataDir string) *Manager {
-	m := &Manager{
-		dataDir:  dataDir,
-		sessions: make(map[string]*Session),
-		courses:  make(map[string]*Course),
-	}
-	
-	// Create directory structure
+// NewManager creates a new session manager for userID, opening (and if
+// necessary creating) its embedded store under
+// <dataDir>/users/<userID>, so each user's recordings are isolated from
+// every other user's. Courses and sessions written by a pre-buntdb
+// version of the Pilot are migrated in. A background sweep brings the
+// transcript search index up to date.
+func NewManager(dataDir, userID string) *Manager {
+	// userID ultimately comes from an untrusted source ($PILOT_USER, or a
+	// name typed into the login window) and is about to become a path
+	// component, so it gets the same treatment as every other ID in this
+	// file - anything but a lowercase alphanumeric/underscore token is
+	// stripped before it can escape the users/ directory.
+	userID = sanitizeID(userID)
+
+	rootDir := dataDir
+	dataDir = filepath.Join(dataDir, "users", userID)
+
 	os.MkdirAll(filepath.Join(dataDir, "recordings"), 0755)
 	os.MkdirAll(filepath.Join(dataDir, "courses"), 0755)
-	
-	// Load existing data
-	m.loadCourses()
-	m.loadSessions()
-	
+
+	db, err := buntdb.Open(filepath.Join(dataDir, dbFileName))
+	if err != nil {
+		log.Fatalf("failed to open session store: %v", err)
+	}
+
+	db.CreateIndex(sessionsByCourseIndex, "session:*", buntdb.IndexJSON("course"))
+	db.CreateIndex(sessionsByCreatedAtIndex, "session:*", sessionCreatedAtLess)
+	db.CreateIndex(coursesByYearSemesterIndex, "course:*", courseYearSemesterLess)
+	db.CreateIndex(readlistsByCreatedAtIndex, "readlist:*", readlistCreatedAtLess)
+
+	scanner, err := search.NewScanner(dataDir)
+	if err != nil {
+		log.Printf("failed to open search index: %v", err)
+	}
+
+	m := &Manager{dataDir: dataDir, db: db, scanner: scanner}
+	if userID == legacyBootstrapUser {
+		// Before multi-user support, everything lived directly under
+		// rootDir: chunk1-1's single-user buntdb, or - before that -
+		// the baseline app's courses.json/sessions.json. Seed the
+		// bootstrap admin's new per-user store from whichever of those
+		// is still sitting there, so upgrading doesn't strand existing
+		// recordings at a path the app no longer looks at.
+		m.migrateLegacyBuntdb(filepath.Join(rootDir, dbFileName))
+		m.migrateLegacyJSON(rootDir)
+	}
+	m.migrateLegacyJSON(dataDir)
+
+	if m.scanner != nil {
+		go m.Reindex()
+	}
+
 	return m
 }
 
+// Close flushes and closes the embedded store and the search index.
+func (m *Manager) Close() error {
+	if m.scanner != nil {
+		if err := m.scanner.Close(); err != nil {
+			log.Printf("failed to close search index: %v", err)
+		}
+	}
+	return m.db.Close()
+}
+
+// Reindex walks every session's transcript file and brings the search
+// index up to date. Sessions whose transcript hasn't changed since the
+// last scan are skipped, so it is cheap enough to run in the background
+// on startup as well as after every UpdateSession.
+func (m *Manager) Reindex() {
+	if m.scanner == nil {
+		return
+	}
+
+	sessions := m.ListSessions("")
+	refs := make([]search.SessionRef, len(sessions))
+	for i, sess := range sessions {
+		refs[i] = search.SessionRef{ID: sess.ID, Course: sess.Course, TranscriptFile: sess.TranscriptFile}
+	}
+	m.scanner.ScanAll(refs)
+}
+
+// Search runs a full-text query over indexed transcripts, optionally
+// restricted to a single course.
+func (m *Manager) Search(query, courseID string) []search.Hit {
+	if m.scanner == nil {
+		return nil
+	}
+
+	hits, err := m.scanner.Search(query, courseID, 50)
+	if err != nil {
+		log.Printf("search failed: %v", err)
+		return nil
+	}
+	return hits
+}
+
 // DataDir returns the data directory path
 func (m *Manager) DataDir() string {
 	return m.dataDir
@@ -75,11 +180,8 @@ func (m *Manager) RecordingsDir() string {
 
 // CreateCourse creates a new course
 func (m *Manager) CreateCourse(name, code string, year int, semester string) (*Course, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	
 	id := fmt.Sprintf("%d_%s_%s", year, semester, sanitizeID(code))
-	
+
 	course := &Course{
 		ID:        id,
 		Name:      name,
@@ -89,122 +191,177 @@ func (m *Manager) CreateCourse(name, code string, year int, semester string) (*C
 		Color:     generateColor(id),
 		CreatedAt: time.Now(),
 	}
-	
+
 	// Create course directory
 	courseDir := filepath.Join(m.dataDir, "courses", id)
 	if err := os.MkdirAll(courseDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create course directory: %w", err)
 	}
-	
-	m.courses[id] = course
-	m.saveCourses()
-	
+
+	if err := m.putCourse(course); err != nil {
+		return nil, err
+	}
+
 	return course, nil
 }
 
 // GetCourse returns a course by ID
 func (m *Manager) GetCourse(id string) (*Course, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	course, ok := m.courses[id]
-	return course, ok
+	var course Course
+	err := m.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(courseKey(id))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(val), &course)
+	})
+	if err != nil {
+		return nil, false
+	}
+	return &course, true
 }
 
-// ListCourses returns all courses
+// ListCourses returns all courses, sorted by year (desc), then
+// semester, then name - an order baked directly into the
+// courses_by_year_semester index, so this is a single Ascend scan.
 func (m *Manager) ListCourses() []*Course {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	
-	courses := make([]*Course, 0, len(m.courses))
-	for _, c := range m.courses {
-		courses = append(courses, c)
-	}
-	
-	// Sort by year (desc), then semester, then name
-	sort.Slice(courses, func(i, j int) bool {
-		if courses[i].Year != courses[j].Year {
-			return courses[i].Year > courses[j].Year
-		}
-		if courses[i].Semester != courses[j].Semester {
-			return courses[i].Semester < courses[j].Semester
-		}
-		return courses[i].Name < courses[j].Name
+	courses := make([]*Course, 0)
+
+	m.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend(coursesByYearSemesterIndex, func(key, value string) bool {
+			var c Course
+			if err := json.Unmarshal([]byte(value), &c); err == nil {
+				courses = append(courses, &c)
+			}
+			return true
+		})
 	})
-	
+
 	return courses
 }
 
 // CreateSession creates a new recording session
 func (m *Manager) CreateSession(courseID, name string) (*Session, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	
 	now := time.Now()
 	id := now.Format("20060102_150405")
-	
-	session := &Session{
+
+	sess := &Session{
 		ID:        id,
 		Name:      name,
 		Course:    courseID,
 		CreatedAt: now,
 	}
-	
+
 	// Determine session directory
-	var sessionDir string
-	if courseID != "" {
-		sessionDir = filepath.Join(m.dataDir, "courses", courseID, id)
-	} else {
-		sessionDir = filepath.Join(m.dataDir, "recordings", id)
-	}
-	
+	sessionDir := m.GetSessionDir(courseID, id)
 	if err := os.MkdirAll(sessionDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create session directory: %w", err)
 	}
-	
-	session.AudioFile = filepath.Join(sessionDir, id+".wav")
-	session.TranscriptFile = filepath.Join(sessionDir, id+".md")
-	
-	m.sessions[id] = session
-	m.saveSessions()
-	
-	return session, nil
+
+	sess.AudioFile = filepath.Join(sessionDir, id+".wav")
+	sess.TranscriptFile = filepath.Join(sessionDir, id+".md")
+
+	if err := m.UpdateSession(sess); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
 }
 
 // GetSession returns a session by ID
 func (m *Manager) GetSession(id string) (*Session, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	session, ok := m.sessions[id]
-	return session, ok
+	var sess Session
+	err := m.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(sessionKey(id))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(val), &sess)
+	})
+	if err != nil {
+		return nil, false
+	}
+	return &sess, true
 }
 
-// ListSessions returns sessions, optionally filtered by course
+// ListSessions returns sessions, optionally filtered by course, newest
+// first. With no course filter this is a single Ascend scan over the
+// created_at index; a course filter scans only that course's sessions
+// via the course index before sorting the (much smaller) result.
 func (m *Manager) ListSessions(courseID string) []*Session {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	
 	sessions := make([]*Session, 0)
-	for _, s := range m.sessions {
-		if courseID == "" || s.Course == courseID {
-			sessions = append(sessions, s)
+
+	m.db.View(func(tx *buntdb.Tx) error {
+		collect := func(key, value string) bool {
+			var s Session
+			if err := json.Unmarshal([]byte(value), &s); err == nil {
+				sessions = append(sessions, &s)
+			}
+			return true
 		}
-	}
-	
-	// Sort by creation time (newest first)
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+
+		if courseID == "" {
+			return tx.Ascend(sessionsByCreatedAtIndex, collect)
+		}
+
+		pivot, err := json.Marshal(struct {
+			Course string `json:"course"`
+		}{Course: courseID})
+		if err != nil {
+			return err
+		}
+		return tx.AscendEqual(sessionsByCourseIndex, string(pivot), collect)
 	})
-	
+
+	if courseID != "" {
+		sort.Slice(sessions, func(i, j int) bool {
+			return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+		})
+	}
+
 	return sessions
 }
 
-// UpdateSession updates a session
-func (m *Manager) UpdateSession(session *Session) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	
-	m.sessions[session.ID] = session
-	return m.saveSessions()
+// UpdateSession creates or updates a session with a single transactional
+// write.
+func (m *Manager) UpdateSession(sess *Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := m.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(sessionKey(sess.ID), string(data), nil)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	if m.scanner != nil {
+		if err := m.scanner.ScanSession(sess.ID, sess.Course, sess.TranscriptFile); err != nil {
+			log.Printf("failed to index session %s: %v", sess.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// putCourse creates or updates a course with a single transactional
+// write.
+func (m *Manager) putCourse(course *Course) error {
+	data, err := json.Marshal(course)
+	if err != nil {
+		return fmt.Errorf("failed to marshal course: %w", err)
+	}
+
+	if err := m.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(courseKey(course.ID), string(data), nil)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to save course: %w", err)
+	}
+
+	return nil
 }
 
 // GetSessionDir returns the directory for a session
@@ -215,82 +372,133 @@ func (m *Manager) GetSessionDir(courseID, sessionID string) string {
 	return filepath.Join(m.dataDir, "recordings", sessionID)
 }
 
-// loadCourses loads courses from disk
-func (m *Manager) loadCourses() error {
-	path := filepath.Join(m.dataDir, "courses.json")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
+// migrateLegacyJSON imports courses.json/sessions.json found in dir and
+// written by a pre-buntdb Pilot into the store, then renames them so a
+// later startup doesn't re-import them.
+func (m *Manager) migrateLegacyJSON(dir string) {
+	m.migrateLegacyFile(filepath.Join(dir, "courses.json"), func(data []byte) error {
+		var courses []*Course
+		if err := json.Unmarshal(data, &courses); err != nil {
+			return err
 		}
-		return err
-	}
-	
-	var courses []*Course
-	if err := json.Unmarshal(data, &courses); err != nil {
-		return err
-	}
-	
-	for _, c := range courses {
-		m.courses[c.ID] = c
-	}
-	
-	return nil
+		for _, c := range courses {
+			if err := m.putCourse(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	m.migrateLegacyFile(filepath.Join(dir, "sessions.json"), func(data []byte) error {
+		var sessions []*Session
+		if err := json.Unmarshal(data, &sessions); err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			if err := m.UpdateSession(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// saveCourses saves courses to disk
-func (m *Manager) saveCourses() error {
-	courses := make([]*Course, 0, len(m.courses))
-	for _, c := range m.courses {
-		courses = append(courses, c)
+// migrateLegacyBuntdb imports every key from the buntdb store at path -
+// the single-user store a pre-multi-user Pilot wrote directly under the
+// app's data directory - into m's own store, then renames it so a later
+// startup doesn't re-import it. Keys and values are copied as-is, since
+// both stores use the same course:/session:/readlist: key scheme.
+func (m *Manager) migrateLegacyBuntdb(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
 	}
-	
-	data, err := json.MarshalIndent(courses, "", "  ")
+
+	legacy, err := buntdb.Open(path)
 	if err != nil {
-		return err
+		log.Printf("failed to open legacy store %s for migration: %v", path, err)
+		return
+	}
+
+	err = legacy.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			if err := m.db.Update(func(wtx *buntdb.Tx) error {
+				_, _, err := wtx.Set(key, value, nil)
+				return err
+			}); err != nil {
+				log.Printf("failed to migrate legacy key %s: %v", key, err)
+			}
+			return true
+		})
+	})
+	legacy.Close()
+	if err != nil {
+		log.Printf("failed to migrate legacy store %s: %v", path, err)
+		return
+	}
+
+	if err := os.Rename(path, path+".migrated"); err != nil {
+		log.Printf("failed to rename migrated legacy store %s: %v", path, err)
 	}
-	
-	path := filepath.Join(m.dataDir, "courses.json")
-	return os.WriteFile(path, data, 0644)
 }
 
-// loadSessions loads sessions from disk
-func (m *Manager) loadSessions() error {
-	path := filepath.Join(m.dataDir, "sessions.json")
+// migrateLegacyFile reads path, if it exists, and hands its bytes to
+// importFn. On success the file is renamed to "<path>.migrated" so it
+// is not imported again on the next startup.
+func (m *Manager) migrateLegacyFile(path string, importFn func(data []byte) error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
+		return
 	}
-	
-	var sessions []*Session
-	if err := json.Unmarshal(data, &sessions); err != nil {
-		return err
+
+	if err := importFn(data); err != nil {
+		log.Printf("failed to migrate %s: %v", path, err)
+		return
 	}
-	
-	for _, s := range sessions {
-		m.sessions[s.ID] = s
+
+	if err := os.Rename(path, path+".migrated"); err != nil {
+		log.Printf("failed to rename migrated file %s: %v", path, err)
 	}
-	
-	return nil
 }
 
-// saveSessions saves sessions to disk
-func (m *Manager) saveSessions() error {
-	sessions := make([]*Session, 0, len(m.sessions))
-	for _, s := range m.sessions {
-		sessions = append(sessions, s)
+// courseKey is the store key for a course.
+func courseKey(id string) string {
+	return "course:" + id
+}
+
+// sessionKey is the store key for a session.
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+// sessionCreatedAtLess orders sessions newest-first by created_at, so
+// Ascend on this index returns them in the same order the UI expects.
+func sessionCreatedAtLess(a, b string) bool {
+	return gjson.Get(a, "created_at").String() > gjson.Get(b, "created_at").String()
+}
+
+// readlistCreatedAtLess orders readlists oldest-first by created_at, so
+// the UI sidebar lists them in the stable order they were created.
+func readlistCreatedAtLess(a, b string) bool {
+	return gjson.Get(a, "created_at").String() < gjson.Get(b, "created_at").String()
+}
+
+// courseYearSemesterLess orders courses by year (desc), then semester,
+// then name (both asc) - the ordering ListCourses previously computed
+// with sort.Slice after loading everything into memory.
+func courseYearSemesterLess(a, b string) bool {
+	ay := gjson.Get(a, "year").Int()
+	by := gjson.Get(b, "year").Int()
+	if ay != by {
+		return ay > by
 	}
-	
-	data, err := json.MarshalIndent(sessions, "", "  ")
-	if err != nil {
-		return err
+
+	as := gjson.Get(a, "semester").String()
+	bs := gjson.Get(b, "semester").String()
+	if as != bs {
+		return as < bs
 	}
-	
-	path := filepath.Join(m.dataDir, "sessions.json")
-	return os.WriteFile(path, data, 0644)
+
+	return gjson.Get(a, "name").String() < gjson.Get(b, "name").String()
 }
 
 // sanitizeID removes special characters from an ID string
@@ -298,7 +506,7 @@ func sanitizeID(s string) string {
 	s = strings.ToLower(s)
 	s = strings.ReplaceAll(s, " ", "_")
 	s = strings.ReplaceAll(s, "-", "_")
-	
+
 	var result strings.Builder
 	for _, r := range s {
 		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
@@ -320,7 +528,7 @@ func generateColor(id string) string {
 		"#06B6D4", // cyan
 		"#F97316", // orange
 	}
-	
+
 	hash := 0
 	for _, c := range id {
 		hash = int(c) + ((hash << 5) - hash)
@@ -328,6 +536,6 @@ func generateColor(id string) string {
 	if hash < 0 {
 		hash = -hash
 	}
-	
+
 	return colors[hash%len(colors)]
 }
@@ -8,26 +8,27 @@ import (
 
 func TestNewManager(t *testing.T) {
 	tmpDir := t.TempDir()
-	
-	m := NewManager(tmpDir)
-	
+
+	m := NewManager(tmpDir, "testuser")
+
 	if m == nil {
 		t.Fatal("NewManager returned nil")
 	}
-	
-	// Check directories were created
-	if _, err := os.Stat(filepath.Join(tmpDir, "recordings")); os.IsNotExist(err) {
+
+	// Check directories were created, rooted under this user
+	userDir := filepath.Join(tmpDir, "users", "testuser")
+	if _, err := os.Stat(filepath.Join(userDir, "recordings")); os.IsNotExist(err) {
 		t.Error("recordings directory was not created")
 	}
-	
-	if _, err := os.Stat(filepath.Join(tmpDir, "courses")); os.IsNotExist(err) {
+
+	if _, err := os.Stat(filepath.Join(userDir, "courses")); os.IsNotExist(err) {
 		t.Error("courses directory was not created")
 	}
 }
 
 func TestCreateCourse(t *testing.T) {
 	tmpDir := t.TempDir()
-	m := NewManager(tmpDir)
+	m := NewManager(tmpDir, "testuser")
 	
 	course, err := m.CreateCourse("Introduction to CS", "CS101", 2024, "Fall")
 	
@@ -52,7 +53,7 @@ func TestCreateCourse(t *testing.T) {
 	}
 	
 	// Verify course directory was created
-	courseDir := filepath.Join(tmpDir, "courses", course.ID)
+	courseDir := filepath.Join(m.DataDir(), "courses", course.ID)
 	if _, err := os.Stat(courseDir); os.IsNotExist(err) {
 		t.Error("Course directory was not created")
 	}
@@ -60,7 +61,7 @@ func TestCreateCourse(t *testing.T) {
 
 func TestCreateSession(t *testing.T) {
 	tmpDir := t.TempDir()
-	m := NewManager(tmpDir)
+	m := NewManager(tmpDir, "testuser")
 	
 	// Create without course
 	sess, err := m.CreateSession("", "Test Lecture")
@@ -92,7 +93,7 @@ func TestCreateSession(t *testing.T) {
 
 func TestListCourses(t *testing.T) {
 	tmpDir := t.TempDir()
-	m := NewManager(tmpDir)
+	m := NewManager(tmpDir, "testuser")
 	
 	// Create multiple courses
 	m.CreateCourse("Course A", "CA100", 2024, "Fall")
@@ -114,7 +115,7 @@ func TestListCourses(t *testing.T) {
 
 func TestListSessions(t *testing.T) {
 	tmpDir := t.TempDir()
-	m := NewManager(tmpDir)
+	m := NewManager(tmpDir, "testuser")
 	
 	course, _ := m.CreateCourse("Test", "T100", 2024, "Fall")
 	
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tidwall/buntdb"
+)
+
+// TestNewManagerMigratesLegacyBuntdb simulates upgrading from chunk1-1's
+// single-user buntdb store: a pilot.db sitting directly under the app's
+// data directory, with no users/ subdirectory yet.
+func TestNewManagerMigratesLegacyBuntdb(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	legacy, err := buntdb.Open(filepath.Join(tmpDir, dbFileName))
+	if err != nil {
+		t.Fatalf("failed to open legacy store fixture: %v", err)
+	}
+	if err := legacy.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(courseKey("cs101"), `{"id":"cs101","name":"Intro to CS","code":"CS101","year":2023,"semester":"Fall"}`, nil)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed legacy store fixture: %v", err)
+	}
+	legacy.Close()
+
+	m := NewManager(tmpDir, legacyBootstrapUser)
+	defer m.Close()
+
+	course, ok := m.GetCourse("cs101")
+	if !ok {
+		t.Fatal("expected course migrated from the legacy buntdb store to be found")
+	}
+	if course.Name != "Intro to CS" {
+		t.Errorf("expected migrated course name 'Intro to CS', got %q", course.Name)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, dbFileName)); !os.IsNotExist(err) {
+		t.Error("expected legacy pilot.db to be renamed after migration")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, dbFileName+".migrated")); err != nil {
+		t.Errorf("expected legacy pilot.db to be renamed to %s.migrated: %v", dbFileName, err)
+	}
+}
+
+// TestNewManagerMigratesLegacyJSONFromRootDir simulates upgrading
+// straight from the baseline JSON-file app: courses.json/sessions.json
+// sitting directly under the app's data directory.
+func TestNewManagerMigratesLegacyJSONFromRootDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	coursesJSON := `[{"id":"cs101","name":"Intro to CS","code":"CS101","year":2023,"semester":"Fall"}]`
+	if err := os.WriteFile(filepath.Join(tmpDir, "courses.json"), []byte(coursesJSON), 0644); err != nil {
+		t.Fatalf("failed to write courses.json fixture: %v", err)
+	}
+
+	sessionsJSON := `[{"id":"20230901_090000","name":"Lecture 1","course":"cs101"}]`
+	if err := os.WriteFile(filepath.Join(tmpDir, "sessions.json"), []byte(sessionsJSON), 0644); err != nil {
+		t.Fatalf("failed to write sessions.json fixture: %v", err)
+	}
+
+	m := NewManager(tmpDir, legacyBootstrapUser)
+	defer m.Close()
+
+	if _, ok := m.GetCourse("cs101"); !ok {
+		t.Error("expected course migrated from root-level courses.json to be found")
+	}
+	if _, ok := m.GetSession("20230901_090000"); !ok {
+		t.Error("expected session migrated from root-level sessions.json to be found")
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "courses.json.migrated")); err != nil {
+		t.Errorf("expected courses.json to be renamed after migration: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "sessions.json.migrated")); err != nil {
+		t.Errorf("expected sessions.json to be renamed after migration: %v", err)
+	}
+}
+
+// TestNewManagerSkipsLegacyMigrationForOtherUsers makes sure only the
+// bootstrap admin account inherits pre-multi-user data - a second local
+// account shouldn't see another user's recordings just because old
+// top-level files happen to still be present.
+func TestNewManagerSkipsLegacyMigrationForOtherUsers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	coursesJSON := `[{"id":"cs101","name":"Intro to CS","code":"CS101","year":2023,"semester":"Fall"}]`
+	if err := os.WriteFile(filepath.Join(tmpDir, "courses.json"), []byte(coursesJSON), 0644); err != nil {
+		t.Fatalf("failed to write courses.json fixture: %v", err)
+	}
+
+	m := NewManager(tmpDir, "someoneelse")
+	defer m.Close()
+
+	if _, ok := m.GetCourse("cs101"); ok {
+		t.Error("expected a non-admin user not to inherit root-level legacy data")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "courses.json")); err != nil {
+		t.Errorf("expected courses.json to be left alone for a non-admin user: %v", err)
+	}
+}
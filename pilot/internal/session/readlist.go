@@ -0,0 +1,240 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+// Readlist is a user-curated, ordered collection of sessions that can
+// span multiple courses - e.g. "Midterm review" or "Everything about
+// Fourier transforms" - surfaced in the UI sidebar alongside Courses
+// and Recordings.
+type Readlist struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+	SessionIDs  []string  `json:"session_ids"`
+}
+
+// CreateReadlist creates a new, empty readlist.
+func (m *Manager) CreateReadlist(name, description string) (*Readlist, error) {
+	base := time.Now().Format("20060102_150405")
+	id := base
+	for n := 2; ; n++ {
+		if _, exists := m.GetReadlist(id); !exists {
+			break
+		}
+		id = fmt.Sprintf("%s_%d", base, n)
+	}
+
+	rl := &Readlist{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Color:       generateColor(id),
+		CreatedAt:   time.Now(),
+		SessionIDs:  []string{},
+	}
+
+	if err := m.putReadlist(rl); err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// GetReadlist returns a readlist by ID.
+func (m *Manager) GetReadlist(id string) (*Readlist, bool) {
+	var rl Readlist
+	err := m.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(readlistKey(id))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(val), &rl)
+	})
+	if err != nil {
+		return nil, false
+	}
+	return &rl, true
+}
+
+// ListReadlists returns every readlist in the stable order they were
+// created, via a single Ascend scan over the readlists_by_created_at
+// index.
+func (m *Manager) ListReadlists() []*Readlist {
+	readlists := make([]*Readlist, 0)
+
+	m.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend(readlistsByCreatedAtIndex, func(key, value string) bool {
+			var rl Readlist
+			if err := json.Unmarshal([]byte(value), &rl); err == nil {
+				readlists = append(readlists, &rl)
+			}
+			return true
+		})
+	})
+
+	return readlists
+}
+
+// AddToReadlist inserts sessionID into the readlist at position,
+// clamped to a valid index. The session must already exist.
+func (m *Manager) AddToReadlist(readlistID, sessionID string, position int) error {
+	rl, ok := m.GetReadlist(readlistID)
+	if !ok {
+		return fmt.Errorf("readlist not found: %s", readlistID)
+	}
+	if _, ok := m.GetSession(sessionID); !ok {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	rl.SessionIDs = insertAt(rl.SessionIDs, sessionID, position)
+
+	return m.putReadlist(rl)
+}
+
+// RemoveFromReadlist removes every occurrence of sessionID from the
+// readlist.
+func (m *Manager) RemoveFromReadlist(readlistID, sessionID string) error {
+	rl, ok := m.GetReadlist(readlistID)
+	if !ok {
+		return fmt.Errorf("readlist not found: %s", readlistID)
+	}
+
+	rl.SessionIDs = removeAll(rl.SessionIDs, sessionID)
+
+	return m.putReadlist(rl)
+}
+
+// ReorderReadlist moves sessionID to position within the readlist.
+func (m *Manager) ReorderReadlist(readlistID, sessionID string, position int) error {
+	rl, ok := m.GetReadlist(readlistID)
+	if !ok {
+		return fmt.Errorf("readlist not found: %s", readlistID)
+	}
+
+	rl.SessionIDs = insertAt(removeAll(rl.SessionIDs, sessionID), sessionID, position)
+
+	return m.putReadlist(rl)
+}
+
+// RemoveSession deletes a session and purges it from every readlist
+// that references it, so a readlist never points at a session that no
+// longer exists.
+func (m *Manager) RemoveSession(id string) error {
+	if err := m.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(sessionKey(id))
+		return err
+	}); err != nil && err != buntdb.ErrNotFound {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	for _, rl := range m.ListReadlists() {
+		if err := m.RemoveFromReadlist(rl.ID, id); err != nil {
+			log.Printf("failed to purge session %s from readlist %s: %v", id, rl.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ExportReadlistMarkdown concatenates every session transcript in a
+// readlist, in readlist order, into a single annotated Markdown
+// document with a heading per session, and writes it to dstPath.
+func (m *Manager) ExportReadlistMarkdown(readlistID, dstPath string) error {
+	rl, ok := m.GetReadlist(readlistID)
+	if !ok {
+		return fmt.Errorf("readlist not found: %s", readlistID)
+	}
+
+	var out []byte
+	out = append(out, fmt.Sprintf("# %s\n\n", rl.Name)...)
+	if rl.Description != "" {
+		out = append(out, fmt.Sprintf("%s\n\n", rl.Description)...)
+	}
+
+	for _, sessionID := range rl.SessionIDs {
+		sess, ok := m.GetSession(sessionID)
+		if !ok {
+			continue
+		}
+
+		out = append(out, fmt.Sprintf("## %s\n\n", sess.Name)...)
+
+		meta := sess.CreatedAt.Format("2006-01-02 15:04")
+		if course, ok := m.GetCourse(sess.Course); ok {
+			meta += " · " + course.Name
+		}
+		out = append(out, fmt.Sprintf("_%s_\n\n", meta)...)
+
+		data, err := os.ReadFile(sess.TranscriptFile)
+		if err != nil {
+			out = append(out, "_(no transcript available)_\n\n"...)
+			continue
+		}
+		out = append(out, data...)
+		out = append(out, "\n\n"...)
+	}
+
+	if err := os.WriteFile(dstPath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write readlist export: %w", err)
+	}
+	return nil
+}
+
+// putReadlist creates or updates a readlist with a single transactional
+// write.
+func (m *Manager) putReadlist(rl *Readlist) error {
+	data, err := json.Marshal(rl)
+	if err != nil {
+		return fmt.Errorf("failed to marshal readlist: %w", err)
+	}
+
+	if err := m.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(readlistKey(rl.ID), string(data), nil)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to save readlist: %w", err)
+	}
+
+	return nil
+}
+
+// readlistKey is the store key for a readlist.
+func readlistKey(id string) string {
+	return "readlist:" + id
+}
+
+// insertAt inserts id into ids at position, clamped to a valid index.
+func insertAt(ids []string, id string, position int) []string {
+	if position < 0 {
+		position = 0
+	}
+	if position > len(ids) {
+		position = len(ids)
+	}
+
+	ids = append(ids, "")
+	copy(ids[position+1:], ids[position:])
+	ids[position] = id
+	return ids
+}
+
+// removeAll returns ids with every occurrence of id removed.
+func removeAll(ids []string, id string) []string {
+	out := ids[:0]
+	for _, existing := range ids {
+		if existing != id {
+			out = append(out, existing)
+		}
+	}
+	return out
+}
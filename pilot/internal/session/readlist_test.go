@@ -0,0 +1,142 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateAndListReadlists(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(tmpDir, "testuser")
+
+	rl, err := m.CreateReadlist("Midterm review", "Everything before the midterm")
+	if err != nil {
+		t.Fatalf("CreateReadlist failed: %v", err)
+	}
+
+	if rl.Name != "Midterm review" {
+		t.Errorf("expected name 'Midterm review', got %q", rl.Name)
+	}
+	if len(rl.SessionIDs) != 0 {
+		t.Errorf("expected a new readlist to be empty, got %d sessions", len(rl.SessionIDs))
+	}
+
+	got, ok := m.GetReadlist(rl.ID)
+	if !ok {
+		t.Fatal("GetReadlist did not find the readlist just created")
+	}
+	if got.Description != "Everything before the midterm" {
+		t.Errorf("expected description to round-trip, got %q", got.Description)
+	}
+
+	second, err := m.CreateReadlist("Finals", "")
+	if err != nil {
+		t.Fatalf("CreateReadlist failed: %v", err)
+	}
+
+	all := m.ListReadlists()
+	if len(all) != 2 {
+		t.Fatalf("expected 2 readlists, got %d", len(all))
+	}
+	if all[0].ID != rl.ID || all[1].ID != second.ID {
+		t.Error("expected ListReadlists to return readlists in creation order")
+	}
+}
+
+func TestAddReorderAndRemoveFromReadlist(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(tmpDir, "testuser")
+
+	rl, _ := m.CreateReadlist("Midterm review", "")
+
+	// Manufactured rather than created via CreateSession, whose ID is a
+	// second-resolution timestamp - three calls in a row can collide on
+	// the same ID and silently merge into one readlist entry.
+	s1 := newTestSession(t, m, "session-1", "Lecture 1")
+	s2 := newTestSession(t, m, "session-2", "Lecture 2")
+	s3 := newTestSession(t, m, "session-3", "Lecture 3")
+
+	if err := m.AddToReadlist(rl.ID, s1.ID, 0); err != nil {
+		t.Fatalf("AddToReadlist failed: %v", err)
+	}
+	if err := m.AddToReadlist(rl.ID, s2.ID, 1); err != nil {
+		t.Fatalf("AddToReadlist failed: %v", err)
+	}
+	if err := m.AddToReadlist(rl.ID, s3.ID, 1); err != nil {
+		t.Fatalf("AddToReadlist failed: %v", err)
+	}
+
+	got, _ := m.GetReadlist(rl.ID)
+	want := []string{s1.ID, s3.ID, s2.ID}
+	if !equalStrings(got.SessionIDs, want) {
+		t.Fatalf("expected order %v, got %v", want, got.SessionIDs)
+	}
+
+	if err := m.ReorderReadlist(rl.ID, s3.ID, 2); err != nil {
+		t.Fatalf("ReorderReadlist failed: %v", err)
+	}
+	got, _ = m.GetReadlist(rl.ID)
+	want = []string{s1.ID, s2.ID, s3.ID}
+	if !equalStrings(got.SessionIDs, want) {
+		t.Fatalf("expected reordered list %v, got %v", want, got.SessionIDs)
+	}
+
+	if err := m.RemoveFromReadlist(rl.ID, s2.ID); err != nil {
+		t.Fatalf("RemoveFromReadlist failed: %v", err)
+	}
+	got, _ = m.GetReadlist(rl.ID)
+	want = []string{s1.ID, s3.ID}
+	if !equalStrings(got.SessionIDs, want) {
+		t.Fatalf("expected list after removal %v, got %v", want, got.SessionIDs)
+	}
+}
+
+func TestRemoveSessionPurgesReadlists(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(tmpDir, "testuser")
+
+	rl, _ := m.CreateReadlist("Midterm review", "")
+	sess, _ := m.CreateSession("", "Lecture 1")
+
+	if err := m.AddToReadlist(rl.ID, sess.ID, 0); err != nil {
+		t.Fatalf("AddToReadlist failed: %v", err)
+	}
+
+	if err := m.RemoveSession(sess.ID); err != nil {
+		t.Fatalf("RemoveSession failed: %v", err)
+	}
+
+	if _, ok := m.GetSession(sess.ID); ok {
+		t.Error("expected session to be gone after RemoveSession")
+	}
+
+	got, _ := m.GetReadlist(rl.ID)
+	if len(got.SessionIDs) != 0 {
+		t.Errorf("expected removed session purged from readlist, got %v", got.SessionIDs)
+	}
+}
+
+// newTestSession stores a session with a manufactured, caller-chosen ID
+// instead of CreateSession's second-resolution timestamp, so a test that
+// needs several distinct sessions isn't at the mercy of how fast it runs.
+func newTestSession(t *testing.T, m *Manager, id, name string) *Session {
+	t.Helper()
+
+	sess := &Session{ID: id, Name: name, CreatedAt: time.Now()}
+	if err := m.UpdateSession(sess); err != nil {
+		t.Fatalf("failed to store test session %s: %v", id, err)
+	}
+	return sess
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+// Package tui provides a headless terminal dashboard for driving the
+// harness controller without a graphical environment.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/topnotchnotes/pilot/internal/ipc"
+	"github.com/topnotchnotes/pilot/internal/session"
+)
+
+// Dashboard renders a live terminal view of a recording session: a dB
+// level bar, a scrolling transcript, and a status footer. It mirrors the
+// information shown by ui.Dashboard but draws directly to the terminal.
+type Dashboard struct {
+	controller  *ipc.Controller
+	sessManager *session.Manager
+
+	mu         sync.Mutex
+	transcript []string
+	level      float64
+	state      string
+	sessionID  string
+	start      time.Time
+
+	out           *bufio.Writer
+	width, height int
+
+	done chan struct{}
+}
+
+// New creates a terminal dashboard bound to the given controller and
+// session manager. Call Run to take over the terminal.
+func New(controller *ipc.Controller, sessManager *session.Manager) *Dashboard {
+	return &Dashboard{
+		controller:  controller,
+		sessManager: sessManager,
+		out:         bufio.NewWriter(os.Stdout),
+		state:       "idle",
+		done:        make(chan struct{}),
+	}
+}
+
+// Run puts stdin into raw mode and drives the dashboard until the user
+// quits (q or Ctrl+C). It restores the terminal before returning.
+func (d *Dashboard) Run() error {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	d.width, d.height, _ = term.GetSize(fd)
+	if d.width == 0 {
+		d.width, d.height = 80, 24
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	d.controller.OnEvent(d.handleEvent)
+	d.render()
+
+	keys := make(chan byte)
+	go d.readKeys(keys)
+
+	for {
+		select {
+		case sig := <-sigCh:
+			if sig == syscall.SIGWINCH {
+				d.width, d.height, _ = term.GetSize(fd)
+			} else {
+				d.shutdown()
+				return nil
+			}
+		case key, ok := <-keys:
+			if !ok || key == 'q' || key == 3 {
+				d.shutdown()
+				return nil
+			}
+		case <-d.done:
+			return nil
+		case <-time.After(200 * time.Millisecond):
+		}
+		d.render()
+	}
+}
+
+// shutdown stops an in-progress recording before Run returns, so both
+// its exit paths - SIGINT and a 'q'/Ctrl+C keypress, the latter being
+// the one that actually fires under raw mode, which disables ISIG -
+// leave the harness in the same graceful state as runRecord does.
+func (d *Dashboard) shutdown() {
+	if d.controller.IsRecording() {
+		d.controller.Stop()
+	}
+}
+
+// readKeys forwards raw keypresses from stdin until it is closed.
+func (d *Dashboard) readKeys(out chan<- byte) {
+	defer close(out)
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return
+		}
+		out <- buf[0]
+	}
+}
+
+// handleEvent updates dashboard state in response to harness telemetry.
+func (d *Dashboard) handleEvent(event ipc.TelemetryEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch event.Event {
+	case ipc.EventText:
+		d.transcript = append(d.transcript, event.Body)
+	case ipc.EventLevel:
+		d.level = event.DB
+	case ipc.EventStatus:
+		d.state = event.State
+	case ipc.EventSession:
+		if event.Action == "start" {
+			d.sessionID = event.ID
+			d.start = time.Now()
+		} else if event.Action == "end" {
+			d.sessionID = ""
+		}
+	}
+}
+
+// render redraws the three panes: level meter, transcript, and footer.
+func (d *Dashboard) render() {
+	d.mu.Lock()
+	level, state, sessionID, start := d.level, d.state, d.sessionID, d.start
+	lines := append([]string(nil), d.transcript...)
+	d.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprint(&b, "\x1b[2J\x1b[H")
+
+	fmt.Fprintf(&b, "%s\r\n", d.levelBar(level))
+
+	transcriptRows := d.height - 4
+	if transcriptRows < 1 {
+		transcriptRows = 1
+	}
+	if len(lines) > transcriptRows {
+		lines = lines[len(lines)-transcriptRows:]
+	}
+	for _, line := range lines {
+		fmt.Fprintf(&b, "%s\r\n", truncate(line, d.width))
+	}
+
+	duration := time.Duration(0)
+	if !start.IsZero() {
+		duration = time.Since(start).Round(time.Second)
+	}
+	fmt.Fprintf(&b, "\x1b[%d;1H-- %s | session %s | %s | q to quit --",
+		d.height, state, sessionID, duration)
+
+	d.out.WriteString(b.String())
+	d.out.Flush()
+}
+
+// levelBar renders the dB level as a fixed-width bar from -60dB to 0dB.
+func (d *Dashboard) levelBar(db float64) string {
+	const width = 40
+	pct := (db + 60) / 60
+	if pct < 0 {
+		pct = 0
+	}
+	if pct > 1 {
+		pct = 1
+	}
+	filled := int(pct * width)
+	return fmt.Sprintf("[%s%s] %6.1f dB",
+		strings.Repeat("#", filled), strings.Repeat(" ", width-filled), db)
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || len(s) <= width {
+		return s
+	}
+	return s[:width]
+}
+
+// Run opens a terminal dashboard bound to controller and sessManager and
+// blocks until the user quits. It is a convenience wrapper around
+// New(...).Run().
+func Run(controller *ipc.Controller, sessManager *session.Manager) error {
+	return New(controller, sessManager).Run()
+}
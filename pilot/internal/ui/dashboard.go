@@ -4,12 +4,14 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"path/filepath"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
@@ -38,7 +40,7 @@ type Dashboard struct {
 	notesText      *widget.Entry
 
 	// Level meter
-	levelBar   *widget.ProgressBar
+	levelChart *LevelHistoryChart
 	levelLabel *widget.Label
 
 	// Status
@@ -46,17 +48,20 @@ type Dashboard struct {
 	durationLabel *widget.Label
 
 	// Session list
-	sessionList *widget.List
-	courseList  *widget.List
+	sessionList  *widget.List
+	courseList   *widget.List
+	readlistList *widget.List
 
 	// Cached data for list updates
-	cachedCourses  []*session.Course
-	cachedSessions []*session.Session
+	cachedCourses   []*session.Course
+	cachedSessions  []*session.Session
+	cachedReadlists []*session.Readlist
 
 	// State
-	recordingStart time.Time
-	currentSession *session.Session
-	selectedCourse string
+	recordingStart   time.Time
+	currentSession   *session.Session
+	selectedCourse   string
+	selectedReadlist string
 }
 
 // NewDashboard creates a new dashboard UI
@@ -82,6 +87,7 @@ func (d *Dashboard) buildUI() {
 	d.pauseBtn.Disable()
 
 	newCourseBtn := widget.NewButtonWithIcon("", theme.FolderNewIcon(), d.onNewCourse)
+	newReadlistBtn := widget.NewButtonWithIcon("", theme.ContentAddIcon(), d.onNewReadlist)
 
 	toolbarBox := container.NewHBox(
 		d.recordBtn,
@@ -89,16 +95,14 @@ func (d *Dashboard) buildUI() {
 		d.pauseBtn,
 		widget.NewSeparator(),
 		newCourseBtn,
+		newReadlistBtn,
 	)
 	d.toolbar = toolbarBox
 
 	// Status bar
 	d.statusLabel = widget.NewLabel("Ready")
 	d.durationLabel = widget.NewLabel("00:00:00")
-	d.levelBar = widget.NewProgressBar()
-	d.levelBar.Min = -60
-	d.levelBar.Max = 0
-	d.levelBar.SetValue(-60)
+	d.levelChart = NewLevelHistoryChart()
 	d.levelLabel = widget.NewLabel("-∞ dB")
 
 	d.statusBar = container.NewBorder(
@@ -112,7 +116,7 @@ func (d *Dashboard) buildUI() {
 		container.NewHBox(
 			d.levelLabel,
 		),
-		d.levelBar,
+		d.levelChart,
 	)
 
 	// Sidebar - Course and Session navigation
@@ -127,6 +131,7 @@ func (d *Dashboard) buildSidebar() {
 	// Initialize cached data
 	d.cachedCourses = d.sessManager.ListCourses()
 	d.cachedSessions = d.sessManager.ListSessions("")
+	d.cachedReadlists = d.sessManager.ListReadlists()
 
 	// Course list
 	d.courseList = widget.NewList(
@@ -166,24 +171,22 @@ func (d *Dashboard) buildSidebar() {
 	d.sessionList = widget.NewList(
 		func() int { return len(d.cachedSessions) },
 		func() fyne.CanvasObject {
-			return container.NewVBox(
-				widget.NewLabel("Session Name"),
-				widget.NewLabel("Date"),
-			)
+			return newSessionRow()
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			if int(id) < len(d.cachedSessions) {
 				s := d.cachedSessions[int(id)]
-				box := obj.(*fyne.Container)
-				nameLabel := box.Objects[0].(*widget.Label)
-				dateLabel := box.Objects[1].(*widget.Label)
+				row := obj.(*sessionRow)
 
 				name := s.Name
 				if name == "" {
 					name = s.ID
 				}
-				nameLabel.SetText(name)
-				dateLabel.SetText(s.CreatedAt.Format("Jan 02, 2006 15:04"))
+				row.nameLabel.SetText(name)
+				row.dateLabel.SetText(s.CreatedAt.Format("Jan 02, 2006 15:04"))
+				row.onSecondary = func(ev *fyne.PointEvent) {
+					d.showSessionContextMenu(s, ev)
+				}
 			}
 		},
 	)
@@ -194,13 +197,44 @@ func (d *Dashboard) buildSidebar() {
 		}
 	}
 
+	// Readlist list
+	d.readlistList = widget.NewList(
+		func() int { return len(d.cachedReadlists) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				canvas.NewCircle(theme.PrimaryColor()),
+				widget.NewLabel("Readlist Name"),
+			)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if int(id) < len(d.cachedReadlists) {
+				rl := d.cachedReadlists[int(id)]
+				box := obj.(*fyne.Container)
+				circle := box.Objects[0].(*canvas.Circle)
+				label := box.Objects[1].(*widget.Label)
+				circle.FillColor = parseColor(rl.Color)
+				label.SetText(rl.Name)
+			}
+		},
+	)
+
+	d.readlistList.OnSelected = func(id widget.ListItemID) {
+		if int(id) < len(d.cachedReadlists) {
+			d.loadReadlist(d.cachedReadlists[int(id)])
+		}
+	}
+
 	// Build sidebar layout
 	courseLabel := widget.NewLabelWithStyle("Courses", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
 	sessionLabel := widget.NewLabelWithStyle("Sessions", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	readlistLabel := widget.NewLabelWithStyle("Readlists", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
 
 	d.sidebar = container.NewVSplit(
 		container.NewBorder(courseLabel, nil, nil, nil, d.courseList),
-		container.NewBorder(sessionLabel, nil, nil, nil, d.sessionList),
+		container.NewVSplit(
+			container.NewBorder(sessionLabel, nil, nil, nil, d.sessionList),
+			container.NewBorder(readlistLabel, nil, nil, nil, d.readlistList),
+		),
 	)
 }
 
@@ -240,8 +274,8 @@ func (d *Dashboard) setupEventHandlers() {
 			d.transcriptText.SetText(current + event.Body)
 			
 		case ipc.EventLevel:
-			// Update level meter
-			d.levelBar.SetValue(event.DB)
+			// Update the numeric readout; the waveform chart itself is
+			// driven by the coalesced OnLevelHistory notifier below.
 			d.levelLabel.SetText(fmt.Sprintf("%.1f dB", event.DB))
 			
 		case ipc.EventStatus:
@@ -258,6 +292,14 @@ func (d *Dashboard) setupEventHandlers() {
 			}
 		}
 	})
+
+	d.controller.OnLevelHistory(func(history []ipc.LevelSample) {
+		// Runs on a background goroutine; chart updates must happen on
+		// the Fyne main thread.
+		fyne.Do(func() {
+			d.levelChart.SetHistory(history)
+		})
+	})
 }
 
 // updateDuration updates the duration display during recording
@@ -290,6 +332,10 @@ func (d *Dashboard) updateButtonStates(state string) {
 		d.recordBtn.Enable()
 		d.stopBtn.Enable()
 		d.pauseBtn.Disable()
+	case "reconnecting":
+		d.recordBtn.Disable()
+		d.stopBtn.Disable()
+		d.pauseBtn.Disable()
 	default: // idle, ready
 		d.recordBtn.Enable()
 		d.stopBtn.Disable()
@@ -327,11 +373,32 @@ func (d *Dashboard) onStop() {
 	if d.currentSession != nil {
 		d.currentSession.Notes = d.notesText.Text
 		d.sessManager.UpdateSession(d.currentSession)
+		d.offerWaveformSave(d.currentSession)
 	}
-	
+
 	d.refreshSessionList()
 }
 
+// offerWaveformSave asks the user whether to save a PNG snapshot of the
+// level history chart alongside the session's transcript.
+func (d *Dashboard) offerWaveformSave(sess *session.Session) {
+	windows := fyne.CurrentApp().Driver().AllWindows()
+	if len(windows) == 0 {
+		return
+	}
+
+	dialog.ShowConfirm("Save waveform?", "Save a PNG snapshot of this recording's level history?",
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			path := filepath.Join(filepath.Dir(sess.TranscriptFile), sess.ID+"_waveform.png")
+			if err := d.levelChart.SaveWaveformPNG(path); err != nil {
+				d.ShowWarning("Failed to save waveform: " + err.Error())
+			}
+		}, windows[0])
+}
+
 func (d *Dashboard) onPause() {
 	if d.controller.State() == "recording" {
 		if err := d.controller.Pause(); err != nil {
@@ -389,13 +456,54 @@ func (d *Dashboard) onNewCourse() {
 		}, fyne.CurrentApp().Driver().AllWindows()[0])
 }
 
+// onNewReadlist shows a dialog for creating a new, empty readlist.
+func (d *Dashboard) onNewReadlist() {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Readlist Name")
+
+	descEntry := widget.NewEntry()
+	descEntry.SetPlaceHolder("Description")
+
+	formItems := []*widget.FormItem{
+		{Text: "Name", Widget: nameEntry},
+		{Text: "Description", Widget: descEntry},
+	}
+
+	dialog.ShowForm("New Readlist", "Create", "Cancel", formItems,
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if _, err := d.sessManager.CreateReadlist(nameEntry.Text, descEntry.Text); err != nil {
+				d.ShowWarning("Failed to create readlist: " + err.Error())
+			}
+			d.refreshReadlistList()
+		}, fyne.CurrentApp().Driver().AllWindows()[0])
+}
+
 func (d *Dashboard) loadSession(sess *session.Session) {
 	d.currentSession = sess
 	d.notesText.SetText(sess.Notes)
 	// TODO: Load transcript from file
 }
 
+// loadReadlist switches the session list over to a readlist's member
+// sessions, in readlist order, instead of a course's sessions.
+func (d *Dashboard) loadReadlist(rl *session.Readlist) {
+	d.selectedReadlist = rl.ID
+
+	sessions := make([]*session.Session, 0, len(rl.SessionIDs))
+	for _, id := range rl.SessionIDs {
+		if sess, ok := d.sessManager.GetSession(id); ok {
+			sessions = append(sessions, sess)
+		}
+	}
+	d.cachedSessions = sessions
+	d.sessionList.Refresh()
+}
+
 func (d *Dashboard) refreshSessionList() {
+	d.selectedReadlist = ""
 	d.cachedSessions = d.sessManager.ListSessions(d.selectedCourse)
 	d.sessionList.Refresh()
 }
@@ -405,6 +513,78 @@ func (d *Dashboard) refreshCourseList() {
 	d.courseList.Refresh()
 }
 
+func (d *Dashboard) refreshReadlistList() {
+	d.cachedReadlists = d.sessManager.ListReadlists()
+	d.readlistList.Refresh()
+}
+
+// showSessionContextMenu opens the right-click menu for a session row,
+// offering to export it as a .tnn bundle or import another bundle.
+func (d *Dashboard) showSessionContextMenu(sess *session.Session, ev *fyne.PointEvent) {
+	windows := fyne.CurrentApp().Driver().AllWindows()
+	if len(windows) == 0 {
+		return
+	}
+
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("Export…", func() { d.onExportSession(sess) }),
+		fyne.NewMenuItem("Import…", func() { d.onImportSession() }),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, windows[0].Canvas(), ev.AbsolutePosition)
+}
+
+// onExportSession prompts for a destination file and writes sess as a
+// portable .tnn bundle.
+func (d *Dashboard) onExportSession(sess *session.Session) {
+	windows := fyne.CurrentApp().Driver().AllWindows()
+	if len(windows) == 0 {
+		return
+	}
+
+	name := sess.Name
+	if name == "" {
+		name = sess.ID
+	}
+
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		dstPath := writer.URI().Path()
+		writer.Close()
+
+		if err := d.sessManager.ExportSession(sess.ID, dstPath); err != nil {
+			d.ShowWarning("Failed to export session: " + err.Error())
+		}
+	}, windows[0])
+	save.SetFileName(name + ".tnn")
+	save.Show()
+}
+
+// onImportSession prompts for a .tnn bundle and adds it as a new
+// session.
+func (d *Dashboard) onImportSession() {
+	windows := fyne.CurrentApp().Driver().AllWindows()
+	if len(windows) == 0 {
+		return
+	}
+
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		if _, err := d.sessManager.ImportSession(reader.URI().Path()); err != nil {
+			d.ShowWarning("Failed to import session: " + err.Error())
+			return
+		}
+		d.refreshSessionList()
+	}, windows[0])
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".tnn"}))
+	open.Show()
+}
+
 // ShowWarning displays a warning message
 func (d *Dashboard) ShowWarning(message string) {
 	windows := fyne.CurrentApp().Driver().AllWindows()
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+
+	"github.com/topnotchnotes/pilot/internal/ipc"
+)
+
+// Level history chart bounds, matching the range the old ProgressBar
+// used (-60dB floor, 0dB ceiling), plus a clip warning line.
+const (
+	levelChartMinDB  = -60.0
+	levelChartMaxDB  = 0.0
+	levelChartClipDB = -3.0
+)
+
+var (
+	levelChartBG     = color.RGBA{R: 24, G: 24, B: 28, A: 255}
+	levelChartFill   = color.RGBA{R: 59, G: 130, B: 246, A: 200} // matches session.generateColor's blue
+	levelChartClip   = color.RGBA{R: 239, G: 68, B: 68, A: 255}  // matches session.generateColor's red
+	levelChartCursor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// LevelHistoryChart draws a rolling dB level history as a filled
+// waveform from -60dB to 0dB, with a clip line at -3dB and a cursor
+// marking the most recent sample.
+type LevelHistoryChart struct {
+	*canvas.Raster
+
+	mu      sync.Mutex
+	history []ipc.LevelSample
+}
+
+// NewLevelHistoryChart creates an empty level history chart.
+func NewLevelHistoryChart() *LevelHistoryChart {
+	chart := &LevelHistoryChart{}
+	chart.Raster = canvas.NewRaster(chart.render)
+	chart.Raster.SetMinSize(fyne.NewSize(200, 40))
+	return chart
+}
+
+// SetHistory replaces the displayed history and redraws the chart. It
+// must be called on the Fyne main thread (see fyne.Do).
+func (c *LevelHistoryChart) SetHistory(history []ipc.LevelSample) {
+	c.mu.Lock()
+	c.history = history
+	c.mu.Unlock()
+
+	canvas.Refresh(c.Raster)
+}
+
+// SaveWaveformPNG rasterizes the current history to a PNG file at the
+// given path, for archiving alongside a session's transcript.
+func (c *LevelHistoryChart) SaveWaveformPNG(path string) error {
+	c.mu.Lock()
+	history := c.history
+	c.mu.Unlock()
+
+	const width, height = 800, 200
+	img := rasterizeLevelHistory(history, width, height)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create waveform file: %w", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("failed to encode waveform PNG: %w", err)
+	}
+	return nil
+}
+
+// render is the canvas.Raster generator function; w/h are in pixels.
+func (c *LevelHistoryChart) render(w, h int) image.Image {
+	c.mu.Lock()
+	history := c.history
+	c.mu.Unlock()
+
+	return rasterizeLevelHistory(history, w, h)
+}
+
+// rasterizeLevelHistory draws history as a filled area chart of size
+// w x h, with a clip line at levelChartClipDB and a cursor at the right
+// edge marking the latest sample.
+func rasterizeLevelHistory(history []ipc.LevelSample, w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, levelChartBG)
+		}
+	}
+
+	if len(history) > 0 && w > 0 {
+		for x := 0; x < w; x++ {
+			idx := x * len(history) / w
+			if idx >= len(history) {
+				idx = len(history) - 1
+			}
+			barTop := h - dbToPixel(history[idx].DB, h)
+			for y := barTop; y < h; y++ {
+				img.Set(x, y, levelChartFill)
+			}
+		}
+
+		cursorX := w - 1
+		for y := 0; y < h; y++ {
+			img.Set(cursorX, y, levelChartCursor)
+		}
+	}
+
+	clipY := h - dbToPixel(levelChartClipDB, h)
+	if clipY >= 0 && clipY < h {
+		for x := 0; x < w; x++ {
+			img.Set(x, clipY, levelChartClip)
+		}
+	}
+
+	return img
+}
+
+// dbToPixel converts a dB value to a pixel height within [0, h], clamped
+// to the chart's [-60, 0] range.
+func dbToPixel(db float64, h int) int {
+	frac := (db - levelChartMinDB) / (levelChartMaxDB - levelChartMinDB)
+	if frac < 0 {
+		frac = 0
+	}
+	if frac > 1 {
+		frac = 1
+	}
+	return int(frac * float64(h))
+}
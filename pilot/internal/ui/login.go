@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/topnotchnotes/pilot/internal/auth"
+)
+
+// ShowLogin renders a sign-in form as w's content and calls onSuccess
+// with the authenticated user once credentials are verified. Nothing
+// else in the Pilot - including the session.Manager - is constructed
+// until a user has signed in.
+func ShowLogin(w fyne.Window, store *auth.Store, onSuccess func(user *auth.User)) {
+	title := widget.NewLabelWithStyle("Sign in to Pilot", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	usernameEntry := widget.NewEntry()
+	usernameEntry.SetPlaceHolder("Username")
+
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("Password")
+
+	errorLabel := widget.NewLabel("")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Username", usernameEntry),
+		widget.NewFormItem("Password", passwordEntry),
+	)
+	form.SubmitText = "Sign in"
+
+	signIn := func() {
+		user, ok := store.Verify(usernameEntry.Text, passwordEntry.Text)
+		if !ok {
+			errorLabel.SetText("Invalid username or password")
+			return
+		}
+		onSuccess(user)
+	}
+	form.OnSubmit = signIn
+	passwordEntry.OnSubmitted = func(string) { signIn() }
+
+	content := container.NewVBox(title, form, errorLabel)
+	w.SetContent(container.NewCenter(content))
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// sessionRow renders one entry in the session list and opens an
+// Export/Import context menu on right-click.
+type sessionRow struct {
+	widget.BaseWidget
+
+	nameLabel *widget.Label
+	dateLabel *widget.Label
+
+	onSecondary func(ev *fyne.PointEvent)
+}
+
+// newSessionRow creates an empty session row template for widget.List.
+func newSessionRow() *sessionRow {
+	row := &sessionRow{
+		nameLabel: widget.NewLabel("Session Name"),
+		dateLabel: widget.NewLabel("Date"),
+	}
+	row.ExtendBaseWidget(row)
+	return row
+}
+
+// CreateRenderer implements fyne.Widget.
+func (r *sessionRow) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewVBox(r.nameLabel, r.dateLabel))
+}
+
+// TappedSecondary implements fyne.SecondaryTappable, opening the
+// row's context menu on right-click.
+func (r *sessionRow) TappedSecondary(ev *fyne.PointEvent) {
+	if r.onSecondary != nil {
+		r.onSecondary(ev)
+	}
+}
+
+var _ fyne.SecondaryTappable = (*sessionRow)(nil)
@@ -4,16 +4,22 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 
+	"github.com/topnotchnotes/pilot/internal/auth"
 	"github.com/topnotchnotes/pilot/internal/ipc"
+	"github.com/topnotchnotes/pilot/internal/serve"
 	"github.com/topnotchnotes/pilot/internal/session"
 	"github.com/topnotchnotes/pilot/internal/ui"
 )
@@ -22,9 +28,31 @@ const (
 	appID      = "com.topnotchnotes.pilot"
 	appName    = "TopNotchNotes"
 	appVersion = "1.0.0"
+
+	// headlessAddr is the address the --headless serve subsystem listens
+	// on for its read-only HTTP API.
+	headlessAddr = ":8420"
 )
 
 func main() {
+	headless := flag.Bool("headless", false, "serve the library over the network instead of opening a window")
+	flag.Parse()
+
+	dataDir := getDataDir()
+
+	userStore, bootstrapPassword, err := auth.NewStore(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to open user store: %v", err)
+	}
+	if bootstrapPassword != "" {
+		log.Printf("No users found - created default account \"admin\" with password: %s", bootstrapPassword)
+	}
+
+	if *headless {
+		runHeadless(dataDir, userStore)
+		return
+	}
+
 	// Initialize the Fyne application
 	a := app.NewWithID(appID)
 	a.SetIcon(theme.DocumentIcon())
@@ -34,11 +62,59 @@ func main() {
 	w.Resize(fyne.NewSize(1200, 800))
 	w.CenterOnScreen()
 
+	// Gate everything else behind a login window; the session.Manager
+	// isn't constructed until a user has signed in.
+	ui.ShowLogin(w, userStore, func(user *auth.User) {
+		startSession(w, dataDir, user)
+	})
+
+	// Run the application
+	w.ShowAndRun()
+}
+
+// runHeadless starts only the network serve subsystem - no window, no
+// harness controller - for running the Pilot on a machine with no
+// display, e.g. a home server sharing a library to a phone or tablet.
+func runHeadless(dataDir string, userStore *auth.Store) {
+	user := headlessUser()
+	sessManager := session.NewManager(dataDir, user)
+	defer sessManager.Close()
+
+	srv := serve.NewServer(sessManager, user, userStore, headlessAddr)
+	if err := srv.Start(true); err != nil {
+		log.Fatalf("Failed to start serve subsystem: %v", err)
+	}
+	log.Printf("Serving library on %s (mDNS: _topnotch._tcp)", headlessAddr)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	<-sigCh
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Stop(ctx); err != nil {
+		log.Printf("Error stopping serve subsystem: %v", err)
+	}
+}
+
+// headlessUser picks the user whose library --headless serves, the
+// same way pilotctl does for its own commands.
+func headlessUser() string {
+	if user := os.Getenv("PILOT_USER"); user != "" {
+		return user
+	}
+	return "admin"
+}
+
+// startSession builds the harness controller, the per-user session
+// manager, and the main dashboard, then replaces the login window's
+// content with it.
+func startSession(w fyne.Window, dataDir string, user *auth.User) {
 	// Determine harness binary path
 	harnessPath := findHarnessBinary()
 
-	// Initialize the session manager
-	sessManager := session.NewManager(getDataDir())
+	// Initialize the session manager, rooted under this user's data
+	sessManager := session.NewManager(dataDir, user.Username)
 
 	// Initialize the process controller (IPC with C++ harness)
 	controller := ipc.NewController(harnessPath)
@@ -48,7 +124,7 @@ func main() {
 
 	// Build the main layout
 	mainContent := container.NewBorder(
-		dashboard.Toolbar(),  // Top: Toolbar with record/stop buttons
+		dashboard.Toolbar(),   // Top: Toolbar with record/stop buttons
 		dashboard.StatusBar(), // Bottom: Status bar with level meter
 		dashboard.Sidebar(),   // Left: Course/session navigation
 		nil,                   // Right: None
@@ -69,6 +145,11 @@ func main() {
 		// Terminate the harness process
 		controller.Terminate()
 
+		// Flush and close the session store
+		if err := sessManager.Close(); err != nil {
+			log.Printf("Error closing session store: %v", err)
+		}
+
 		// Close the window
 		w.Close()
 	})
@@ -78,9 +159,6 @@ func main() {
 		log.Printf("Warning: Could not start harness: %v", err)
 		dashboard.ShowWarning("Harness not available. Recording will be simulated.")
 	}
-
-	// Run the application
-	w.ShowAndRun()
 }
 
 // findHarnessBinary locates the C++ harness binary